@@ -29,9 +29,11 @@ resource "megaport_aws_vxc" "foo" {
   }
 
   b_end {
-    product_uid    = data.megaport_partner_port.aws.id
-    aws_account_id = "{{ .aws_account_id }}"
-    customer_asn   = {{ .customer_asn }}
-    type           = "private"
+    product_uid         = data.megaport_partner_port.aws.id
+    aws_account_id      = "{{ .aws_account_id }}"
+    customer_asn        = {{ .customer_asn }}
+    aws_ip_address      = "10.0.0.1/30"
+    customer_ip_address = "10.0.0.2/30"
+    type                = "private"
   }
 }
@@ -0,0 +1,12 @@
+data "megaport_location" "foo" {
+  name_regex = "{{ .location }}"
+}
+
+data "megaport_internet_exchanges" "all" {
+  location_id = data.megaport_location.foo.id
+}
+
+data "megaport_internet_exchanges" "lon1" {
+  location_id = data.megaport_location.foo.id
+  name_regex  = "LON1"
+}
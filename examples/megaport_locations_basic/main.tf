@@ -0,0 +1,8 @@
+data "megaport_locations" "foo" {
+  metro      = "{{ .metro }}"
+  port_speed = 10000
+}
+
+data "megaport_location" "foo" {
+  site_code = data.megaport_locations.foo.locations.0.site_code
+}
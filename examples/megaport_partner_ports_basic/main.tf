@@ -0,0 +1,20 @@
+data "megaport_location" "aws" {
+  name_regex = "{{ .location }}"
+}
+
+data "megaport_partner_ports" "aws" {
+  company_name = "AWS"
+  connect_type = "AWS"
+  location_id  = data.megaport_location.aws.id
+  min_speed    = 10000
+}
+
+data "megaport_partner_ports" "aws_blue" {
+  connect_type   = "AWS"
+  location_id    = data.megaport_location.aws.id
+  diversity_zone = "blue"
+}
+
+data "megaport_partner_port" "aws" {
+  product_uid = data.megaport_partner_ports.aws.partner_ports.0.product_uid
+}
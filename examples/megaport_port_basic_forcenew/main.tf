@@ -9,5 +9,6 @@ resource "megaport_port" "foo" {
   term                   = 12
   invoice_reference      = "{{ .uid }}"
   marketplace_visibility = "public"
+  diversity_zone         = "red"
 }
 
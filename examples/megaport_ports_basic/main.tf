@@ -0,0 +1,32 @@
+data "megaport_location" "foo" {
+  name_regex = "{{ .location }}"
+}
+
+resource "megaport_port" "foo" {
+  name              = "terraform_acctest_a_{{ .uid }}"
+  location_id       = data.megaport_location.foo.id
+  speed             = 1000
+  term              = 1
+  invoice_reference = "{{ .uid }}"
+}
+
+resource "megaport_port" "bar" {
+  name              = "terraform_acctest_b_{{ .uid }}"
+  location_id       = data.megaport_location.foo.id
+  speed             = 1000
+  term              = 1
+  invoice_reference = "{{ .uid }}"
+}
+
+resource "megaport_private_vxc" "foo" {
+  name       = "terraform_acctest_{{ .uid }}"
+  rate_limit = 100
+
+  a_end {
+    product_uid = megaport_port.foo.id
+  }
+
+  b_end {
+    product_uid = megaport_port.bar.id
+  }
+}
@@ -0,0 +1,28 @@
+data "megaport_location" "foo" {
+  name_regex = "{{ .location }}"
+}
+
+data "megaport_location" "bar" {
+  name_regex = "{{ .location_b }}"
+}
+
+data "megaport_price" "port_monthly" {
+  product_type = "port"
+  location_id  = data.megaport_location.foo.id
+  speed        = 10000
+  term         = 1
+}
+
+data "megaport_price" "port_yearly" {
+  product_type = "port"
+  location_id  = data.megaport_location.foo.id
+  speed        = 10000
+  term         = 12
+}
+
+data "megaport_price" "vxc" {
+  product_type  = "vxc"
+  a_location_id = data.megaport_location.foo.id
+  b_location_id = data.megaport_location.bar.id
+  speed         = 100
+}
@@ -0,0 +1,15 @@
+data "megaport_location" "foo" {
+  name_regex = "{{ .location }}"
+}
+
+resource "megaport_port" "foo" {
+  name        = "terraform_acctest_{{ .uid }}"
+  location_id = data.megaport_location.foo.id
+  speed       = 1000
+  term        = 1
+}
+
+data "megaport_product_activity" "foo" {
+  product_uid = megaport_port.foo.id
+  max_events  = 5
+}
@@ -0,0 +1,57 @@
+data "megaport_location" "foo" {
+  name_regex = "{{ .location }}"
+}
+
+resource "megaport_port" "foo" {
+  name        = "terraform_acctest_a_{{ .uid }}"
+  location_id = data.megaport_location.foo.id
+  speed       = 1000
+  term        = 1
+}
+
+resource "megaport_port" "bar" {
+  name        = "terraform_acctest_b_{{ .uid }}"
+  location_id = data.megaport_location.foo.id
+  speed       = 1000
+  term        = 1
+}
+
+resource "megaport_vlan_allocation" "foo" {
+  product_uid = megaport_port.foo.id
+  vlan_min    = 100
+  vlan_max    = 109
+}
+
+resource "megaport_vlan_allocation" "bar" {
+  product_uid = megaport_port.foo.id
+  vlan_min    = 100
+  vlan_max    = 109
+}
+
+resource "megaport_private_vxc" "foo" {
+  name       = "terraform_acctest_a_{{ .uid }}"
+  rate_limit = 100
+
+  a_end {
+    product_uid = megaport_port.foo.id
+    vlan        = megaport_vlan_allocation.foo.vlan
+  }
+
+  b_end {
+    product_uid = megaport_port.bar.id
+  }
+}
+
+resource "megaport_private_vxc" "bar" {
+  name       = "terraform_acctest_b_{{ .uid }}"
+  rate_limit = 100
+
+  a_end {
+    product_uid = megaport_port.foo.id
+    vlan        = megaport_vlan_allocation.bar.vlan
+  }
+
+  b_end {
+    product_uid = megaport_port.bar.id
+  }
+}
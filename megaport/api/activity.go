@@ -0,0 +1,40 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// GetProductActivity returns the action log of a single product, most recent
+// entry first.
+func (c *Client) GetProductActivity(uid string) ([]*ActivityLog, error) {
+	return c.getActivity(fmt.Sprintf("%s/v2/product/%s/logs", c.BaseURL, uid))
+}
+
+// GetCompanyActivity returns the action log of every product owned by the
+// company the client is authenticated as, most recent entry first.
+func (c *Client) GetCompanyActivity() ([]*ActivityLog, error) {
+	return c.getActivity(fmt.Sprintf("%s/v2/company/logs", c.BaseURL))
+}
+
+func (c *Client) getActivity(u string) ([]*ActivityLog, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := []*ActivityLog{}
+	if err := c.do(req, &data); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].CreateDate > data[j].CreateDate
+	})
+	return data, nil
+}
+
+// Time returns the time at which the logged action took place.
+func (v *ActivityLog) Time() time.Time {
+	return time.Unix(0, int64(v.CreateDate)*int64(time.Millisecond)).UTC()
+}
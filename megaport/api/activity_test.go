@@ -0,0 +1,40 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestClient_GetProductActivity(t *testing.T) {
+	uid := uuid.New().String()
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/product/"+uid+"/logs" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":[
+			{"productUid":"%[1]s","createDate":1577836800000.0,"interface":"API","logType":"CREATE","userName":"a"},
+			{"productUid":"%[1]s","createDate":1580515200000.0,"interface":"PORTAL","logType":"UPDATE","userName":"b"}
+		]}`, uid)
+	})
+	defer s.Close()
+	logs, err := c.GetProductActivity(uid)
+	if err != nil {
+		t.Fatalf("TestClient_GetProductActivity: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("TestClient_GetProductActivity: unexpected number of entries: got %d, expected 2", len(logs))
+	}
+	if logs[0].LogType != "UPDATE" || logs[0].Interface != "PORTAL" || logs[0].UserName != "b" {
+		t.Errorf("TestClient_GetProductActivity: expected the most recent entry first, got %#v", logs[0])
+	}
+	e := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	if !logs[0].Time().Equal(e) {
+		t.Errorf("TestClient_GetProductActivity: unexpected time: got %s, expected %s", logs[0].Time(), e)
+	}
+}
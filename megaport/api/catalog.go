@@ -0,0 +1,114 @@
+package api
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Catalog caches the lists of locations, partner ports, ports and managed
+// companies of a client, which are expensive to fetch and looked up
+// repeatedly by data sources.
+// Entries are refreshed once they are older than the TTL; a TTL of zero keeps
+// them until they are invalidated.
+type Catalog struct {
+	c   *Client
+	TTL time.Duration
+
+	now          func() time.Time
+	locations    catalogEntry
+	partnerPorts catalogEntry
+	ports        catalogEntry
+	managed      catalogEntry
+}
+
+type catalogEntry struct {
+	mu      sync.Mutex
+	value   interface{}
+	fetched time.Time
+}
+
+func NewCatalog(c *Client, ttl time.Duration) *Catalog {
+	return &Catalog{
+		c:   c,
+		TTL: ttl,
+		now: time.Now,
+	}
+}
+
+func (c *Catalog) Locations() ([]*Location, error) {
+	v, err := c.get(&c.locations, "locations", func() (interface{}, error) {
+		return c.c.GetLocations()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*Location), nil
+}
+
+func (c *Catalog) PartnerPorts() ([]*Megaport, error) {
+	v, err := c.get(&c.partnerPorts, "partner ports", func() (interface{}, error) {
+		return c.c.GetMegaports() // TODO: rename in api
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*Megaport), nil
+}
+
+func (c *Catalog) Ports() ([]*Product, error) {
+	v, err := c.get(&c.ports, "ports", func() (interface{}, error) {
+		return c.c.ListPorts()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*Product), nil
+}
+
+func (c *Catalog) ManagedCompanies() ([]*ManagedCompany, error) {
+	v, err := c.get(&c.managed, "managed companies", func() (interface{}, error) {
+		return c.c.ListManagedCompanies()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*ManagedCompany), nil
+}
+
+// InvalidatePorts drops the cached ports, which also carry their associated
+// VXCs. It should be called after products are created, updated or deleted.
+func (c *Catalog) InvalidatePorts() {
+	c.ports.invalidate()
+}
+
+// Invalidate drops every cached list.
+func (c *Catalog) Invalidate() {
+	c.locations.invalidate()
+	c.partnerPorts.invalidate()
+	c.ports.invalidate()
+	c.managed.invalidate()
+}
+
+func (c *Catalog) get(e *catalogEntry, name string, fetch func() (interface{}, error)) (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := c.now()
+	if e.value != nil && (c.TTL <= 0 || now.Sub(e.fetched) < c.TTL) {
+		return e.value, nil
+	}
+	log.Printf("Updating %s list", name)
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	e.value = v
+	e.fetched = now
+	return v, nil
+}
+
+func (e *catalogEntry) invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value = nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCatalog(t *testing.T) {
+	requests := map[string]int{}
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		fmt.Fprint(w, `{"message":"","data":[]}`)
+	})
+	defer s.Close()
+	now := time.Unix(0, 0)
+	cat := NewCatalog(c, time.Minute)
+	cat.now = func() time.Time { return now }
+	get := func() {
+		if _, err := cat.Locations(); err != nil {
+			t.Fatalf("TestCatalog: %v", err)
+		}
+		if _, err := cat.PartnerPorts(); err != nil {
+			t.Fatalf("TestCatalog: %v", err)
+		}
+		if _, err := cat.Ports(); err != nil {
+			t.Fatalf("TestCatalog: %v", err)
+		}
+	}
+	expect := func(step string, locations, partnerPorts, ports int) {
+		if requests["/v2/locations"] != locations || requests["/v2/dropdowns/partner/megaports"] != partnerPorts || requests["/v2/products"] != ports {
+			t.Errorf("TestCatalog (%s): unexpected requests: %v", step, requests)
+		}
+	}
+	get()
+	get()
+	expect("cached", 1, 1, 1)
+	cat.InvalidatePorts()
+	get()
+	expect("ports invalidated", 1, 1, 2)
+	now = now.Add(time.Minute)
+	get()
+	expect("expired", 2, 2, 3)
+	cat.Invalidate()
+	get()
+	expect("invalidated", 3, 3, 4)
+	cat.TTL = 0
+	now = now.Add(time.Hour)
+	get()
+	expect("no ttl", 3, 3, 4)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -18,20 +20,48 @@ const (
 	Version   = "0.1"
 	UserAgent = "megaport-api-go-client/" + Version
 
-	ProductStatusDecommissioned  = "DECOMMISSIONED"
-	ProductStatusCancelled       = "CANCELLED"
-	ProductStatusCancelledParent = "CANCELLED_PARENT"
+	// Deprecated: use ProvisioningStatusDecommissioned.
+	ProductStatusDecommissioned = string(ProvisioningStatusDecommissioned)
+	// Deprecated: use ProvisioningStatusCancelled.
+	ProductStatusCancelled = string(ProvisioningStatusCancelled)
+	// Deprecated: use ProvisioningStatusCancelledParent.
+	ProductStatusCancelledParent = string(ProvisioningStatusCancelledParent)
 )
 
 var (
 	ErrNotFound = fmt.Errorf("megaport-api: not found")
 )
 
+// ResponseError is returned when the api responds with an unexpected status.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *ResponseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("megaport-api: %s: %v", e.Message, e.Err)
+	}
+	return fmt.Sprintf("megaport-api: %s", e.Message)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
 type Client struct {
 	c         *http.Client
 	BaseURL   string
 	Token     string
 	UserAgent string
+	// CompanyUid, when set, scopes every request to a company managed by the
+	// authenticated partner. See ForCompany.
+	CompanyUid string
+	// SchemaDrift controls what happens when a response contains fields that
+	// are unknown to the client: they are either ignored (the default),
+	// logged or cause the request to fail.
+	SchemaDrift string
 }
 
 func NewClient(baseURL string) *Client {
@@ -39,6 +69,11 @@ func NewClient(baseURL string) *Client {
 	return c
 }
 
+// SetTransport replaces the transport used to issue requests to the api.
+func (c *Client) SetTransport(t http.RoundTripper) {
+	c.c.Transport = t
+}
+
 func (c *Client) Login(username, password, otp string) error {
 	v := url.Values{}
 	v.Set("username", username)
@@ -171,6 +206,9 @@ func (c *Client) do(req *http.Request, data interface{}) error {
 	if c.Token != "" {
 		req.Header.Set("X-Auth-Token", c.Token)
 	}
+	if c.CompanyUid != "" {
+		req.Header.Set(companyUidHeader, c.CompanyUid)
+	}
 	resp, err := c.c.Do(req)
 	if err != nil {
 		return err
@@ -183,14 +221,32 @@ func (c *Client) do(req *http.Request, data interface{}) error {
 		if err := parseResponseBody(resp, &r); err != nil {
 			return err
 		}
-		err := responseDataToError(r.Data)
-		if err != nil {
-			return fmt.Errorf("megaport-api: %s: %w", r.Message, err)
-		} else {
-			return fmt.Errorf("megaport-api: %s", r.Message)
+		return &ResponseError{
+			StatusCode: resp.StatusCode,
+			Message:    r.Message,
+			Err:        responseDataToError(r.Data),
 		}
 	}
-	return parseResponseBody(resp, &megaportResponse{Data: data})
+	r := megaportRawResponse{}
+	if err := parseResponseBody(resp, &r); err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	d := &decoder{}
+	if err := d.unmarshal(r.Data, data); err != nil {
+		return err
+	}
+	if err := d.drift(); err != nil {
+		switch c.SchemaDrift {
+		case SchemaDriftFail:
+			return fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+		case SchemaDriftLog:
+			log.Printf("[WARN] %s %s: %v", req.Method, req.URL.Path, err)
+		}
+	}
+	return nil
 }
 
 func responseDataToError(d interface{}) error {
@@ -198,9 +254,14 @@ func responseDataToError(d interface{}) error {
 	case string:
 		return fmt.Errorf("%s", e)
 	case map[string]interface{}:
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
 		errData := &strings.Builder{}
-		for k, v := range e {
-			if _, err := fmt.Fprintf(errData, "%s=%#v ", k, v); err != nil {
+		for _, k := range keys {
+			if _, err := fmt.Fprintf(errData, "%s=%#v ", k, e[k]); err != nil {
 				return err
 			}
 		}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -137,3 +138,80 @@ func TestClient_responseDataToError(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_SchemaDrift(t *testing.T) {
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"","data":{"id":1,"name":"foo","newField":true}}`)
+	})
+	defer s.Close()
+	for _, mode := range []string{"", SchemaDriftIgnore, SchemaDriftLog} {
+		c.SchemaDrift = mode
+		req, err := http.NewRequest(http.MethodGet, s.URL+"/v2/locations", nil)
+		if err != nil {
+			t.Fatalf("TestClient_SchemaDrift: %v", err)
+		}
+		l := &Location{}
+		if err := c.do(req, l); err != nil {
+			t.Errorf("TestClient_SchemaDrift (%q): %v", mode, err)
+		}
+		if l.Name != "foo" || string(l.Raw.Unknown["newField"]) != "true" {
+			t.Errorf("TestClient_SchemaDrift (%q): unexpected location: %#v", mode, l)
+		}
+	}
+	c.SchemaDrift = SchemaDriftFail
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/v2/locations", nil)
+	if err != nil {
+		t.Fatalf("TestClient_SchemaDrift: %v", err)
+	}
+	err = c.do(req, &Location{})
+	e := &SchemaDriftError{}
+	if !errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0] != "Location.newField" {
+		t.Errorf("TestClient_SchemaDrift: unexpected error: %v", err)
+	}
+}
+
+func TestClient_ForCompany(t *testing.T) {
+	companyUid := uuid.New().String()
+	headers := []string{}
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Company-Uid"))
+		fmt.Fprintf(w, `{"message":"","data":[{"accountName":"foo","accountRef":"REF-1","companyUid":"%s"}]}`, companyUid)
+	})
+	defer s.Close()
+	mc, err := c.ListManagedCompanies()
+	if err != nil {
+		t.Fatalf("TestClient_ForCompany: %v", err)
+	}
+	if len(mc) != 1 || mc[0].AccountName != "foo" || mc[0].CompanyUid != companyUid {
+		t.Errorf("TestClient_ForCompany: unexpected managed companies: %#v", mc)
+	}
+	if _, err := c.ForCompany(companyUid).ListManagedCompanies(); err != nil {
+		t.Fatalf("TestClient_ForCompany: %v", err)
+	}
+	if c.CompanyUid != "" {
+		t.Errorf("TestClient_ForCompany: the original client was modified: %q", c.CompanyUid)
+	}
+	if len(headers) != 2 || headers[0] != "" || headers[1] != companyUid {
+		t.Errorf("TestClient_ForCompany: unexpected X-Company-Uid headers: %q", headers)
+	}
+}
+
+func TestClient_ListManagedCompanies_refused(t *testing.T) {
+	status := http.StatusForbidden
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"message":"Access denied","data":null}`)
+	})
+	defer s.Close()
+	mc, err := c.ListManagedCompanies()
+	if err != nil {
+		t.Fatalf("TestClient_ListManagedCompanies_refused: %v", err)
+	}
+	if len(mc) != 0 {
+		t.Errorf("TestClient_ListManagedCompanies_refused: unexpected managed companies: %#v", mc)
+	}
+	status = http.StatusInternalServerError
+	if _, err := c.ListManagedCompanies(); err == nil {
+		t.Errorf("TestClient_ListManagedCompanies_refused: expected an error for status %d", status)
+	}
+}
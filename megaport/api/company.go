@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// TODO: scoping requests to a managed company is not documented on
+// dev.megaport.com and has not been checked against a recorded response, it
+// mirrors what the portal does for partners. It is unsupported until then.
+const companyUidHeader = "X-Company-Uid"
+
+// ForCompany returns a copy of the client whose requests act on behalf of the
+// given company, which must be managed by the company the client is
+// authenticated as. An empty uid returns a client for the authenticated
+// company itself.
+func (c *Client) ForCompany(uid string) *Client {
+	cc := *c
+	cc.CompanyUid = uid
+	return &cc
+}
+
+// ListManagedCompanies returns the companies managed by the partner the client
+// is authenticated as. Companies that are not partners are refused access to
+// the list, which is returned as empty instead.
+func (c *Client) ListManagedCompanies() ([]*ManagedCompany, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v2/managedCompanies", c.BaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	data := []*ManagedCompany{}
+	if err := c.do(req, &data); err != nil {
+		var re *ResponseError
+		if err == ErrNotFound || errors.As(err, &re) && re.StatusCode < http.StatusInternalServerError {
+			return []*ManagedCompany{}, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
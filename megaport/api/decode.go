@@ -0,0 +1,373 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SchemaDriftIgnore = "ignore"
+	SchemaDriftLog    = "log"
+	SchemaDriftFail   = "fail"
+)
+
+var (
+	jsonNull      = []byte("null")
+	rawFieldsType = reflect.TypeOf(RawFields{})
+	pathIndexRe   = regexp.MustCompile(`\[[^\]]*\]`)
+)
+
+// RawFields holds the JSON an api object was decoded from, along with those of
+// its fields that have no counterpart in the Go type, so that fields added to
+// the api can be read before they are supported.
+type RawFields struct {
+	JSON    json.RawMessage
+	Unknown map[string]json.RawMessage
+}
+
+// Get decodes the named field of the raw object into v, matching the name in
+// the same way as the field names of Go types are matched. It returns false if
+// the object has no such field.
+func (r RawFields) Get(name string, v interface{}) (bool, error) {
+	if len(r.JSON) == 0 {
+		return false, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(r.JSON, &fields); err != nil {
+		return false, err
+	}
+	raw, ok := fields[name]
+	if !ok {
+		raw, ok = fields[foldFieldNames(fields)[strings.ToLower(name)]]
+	}
+	if !ok {
+		return false, nil
+	}
+	return true, Unmarshal(raw, v)
+}
+
+// SchemaDriftError lists the fields of an api response that are not known to
+// the client.
+type SchemaDriftError struct {
+	Fields []string
+}
+
+func (e *SchemaDriftError) Error() string {
+	return fmt.Sprintf("megaport-api: response contains unknown fields: %s", strings.Join(e.Fields, ", "))
+}
+
+// DecodeError reports a field of an api response that could not be decoded.
+type DecodeError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("megaport-api: cannot decode %s from %s: %v", e.Field, e.Value, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Unmarshal decodes JSON into v in the same way as encoding/json, except that
+// integer and float fields also accept floats, numeric strings and null, since
+// the api is not consistent in the way it encodes numbers. Floats must be
+// whole numbers to be decoded into integer fields.
+//
+// Fields of type RawFields are populated with the JSON of the object that
+// contains them and with the fields of that object that were not decoded.
+func Unmarshal(data []byte, v interface{}) error {
+	return (&decoder{}).unmarshal(data, v)
+}
+
+type decoder struct {
+	unknown map[string]bool
+}
+
+func (d *decoder) unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("megaport-api: cannot decode into %T, a non-nil pointer is required", v)
+	}
+	return d.decodeValue(typeName(rv.Type()), data, rv.Elem())
+}
+
+// drift returns the unknown fields seen by the decoder, or nil if there were
+// none. Indices are omitted from the field paths.
+func (d *decoder) drift() error {
+	if len(d.unknown) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(d.unknown))
+	for f := range d.unknown {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return &SchemaDriftError{Fields: fields}
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func (d *decoder) decodeValue(path string, raw []byte, v reflect.Value) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, jsonNull) {
+		return nil
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		if u, ok := v.Addr().Interface().(json.Unmarshaler); ok {
+			return decodeError(path, raw, u.UnmarshalJSON(raw))
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decodeValue(path, raw, v.Elem())
+	case reflect.Struct:
+		return d.decodeStruct(path, raw, v)
+	case reflect.Slice:
+		items := []json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return decodeError(path, raw, err)
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := d.decodeValue(fmt.Sprintf("%s[%d]", path, i), item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		items := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return decodeError(path, raw, err)
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(items))
+		for k, item := range items {
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decodeValue(fmt.Sprintf("%s[%q]", path, k), item, e); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), e)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseInt(raw)
+		if err == nil && v.OverflowInt(n) {
+			err = fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		if err != nil {
+			return decodeError(path, raw, err)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseUint(raw)
+		if err == nil && v.OverflowUint(n) {
+			err = fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		if err != nil {
+			return decodeError(path, raw, err)
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := parseFloat(raw)
+		if err != nil {
+			return decodeError(path, raw, err)
+		}
+		v.SetFloat(n)
+		return nil
+	}
+	return decodeError(path, raw, json.Unmarshal(raw, v.Addr().Interface()))
+}
+
+func (d *decoder) decodeStruct(path string, raw []byte, v reflect.Value) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return decodeError(path, raw, err)
+	}
+	used := map[string]bool{}
+	if err := d.decodeFields(path, fields, foldFieldNames(fields), used, v); err != nil {
+		return err
+	}
+	unknown := map[string]json.RawMessage{}
+	for k, f := range fields {
+		if used[k] {
+			continue
+		}
+		unknown[k] = f
+		if d.unknown == nil {
+			d.unknown = map[string]bool{}
+		}
+		d.unknown[pathIndexRe.ReplaceAllString(path, "[]")+"."+k] = true
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Type == rawFieldsType && v.Field(i).CanSet() {
+			v.Field(i).Set(reflect.ValueOf(RawFields{JSON: append(json.RawMessage{}, raw...), Unknown: unknown}))
+		}
+	}
+	return nil
+}
+
+// foldFieldNames maps the lower case form of each field name to the field
+// name, preferring the first in lexical order when several fold to the same.
+func foldFieldNames(fields map[string]json.RawMessage) map[string]string {
+	folded := map[string]string{}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := folded[strings.ToLower(k)]; !ok {
+			folded[strings.ToLower(k)] = k
+		}
+	}
+	return folded
+}
+
+func (d *decoder) decodeFields(path string, fields map[string]json.RawMessage, folded map[string]string, used map[string]bool, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonFieldName(f)
+		if !ok {
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			if err := d.decodeFields(path, fields, folded, used, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		key := name
+		raw, ok := fields[key]
+		if !ok {
+			key = folded[strings.ToLower(name)]
+			raw, ok = fields[key]
+		}
+		if !ok {
+			continue
+		}
+		used[key] = true
+		if err := d.decodeValue(path+"."+f.Name, raw, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonFieldName returns the name given to a field by its json tag, and false
+// if the field is excluded from decoding.
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	return strings.Split(tag, ",")[0], true
+}
+
+func decodeError(path string, raw []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*DecodeError); ok {
+		return err
+	}
+	return &DecodeError{Field: path, Value: string(raw), Err: err}
+}
+
+// numericText returns the text of a JSON number, or of a JSON string holding a
+// number. An empty string is treated as zero.
+func numericText(raw []byte) (string, error) {
+	if raw[0] == '"' {
+		s := ""
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", err
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return "0", nil
+		}
+		return s, nil
+	}
+	if raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9') {
+		return string(raw), nil
+	}
+	return "", fmt.Errorf("expected a number")
+}
+
+func parseFloat(raw []byte) (float64, error) {
+	s, err := numericText(raw)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return f, nil
+}
+
+func parseInt(raw []byte) (int64, error) {
+	s, err := numericText(raw)
+	if err != nil {
+		return 0, err
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%s is not an integer", s)
+	}
+	return int64(f), nil
+}
+
+func parseUint(raw []byte) (uint64, error) {
+	s, err := numericText(raw)
+	if err != nil {
+		return 0, err
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, fmt.Errorf("%s is not an unsigned integer", s)
+	}
+	return uint64(f), nil
+}
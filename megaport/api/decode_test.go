@@ -0,0 +1,122 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		p string
+		o Location
+	}{
+		{ // 0
+			p: `{"id":42,"liveDate":1467201600000,"products":{"mcrVersion":2,"megaport":[1,10,100]}}`,
+			o: Location{Id: 42, LiveDate: 1467201600000, Products: LocationProducts{MCRVersion: 2, Megaport: []uint64{1, 10, 100}}},
+		},
+		{ // 1
+			p: `{"id":42.0,"liveDate":1.4672016e12,"products":{"mcrVersion":"2","megaport":["1","10.0",100]}}`,
+			o: Location{Id: 42, LiveDate: 1467201600000, Products: LocationProducts{MCRVersion: 2, Megaport: []uint64{1, 10, 100}}},
+		},
+		{ // 2
+			p: `{"id":null,"liveDate":"","latitude":"51.5","longitude":-0.25,"name":"foo"}`,
+			o: Location{Latitude: 51.5, Longitude: -0.25, Name: "foo"},
+		},
+	}
+	for i, tc := range testCases {
+		v := Location{}
+		if err := Unmarshal([]byte(tc.p), &v); err != nil {
+			t.Errorf("Unmarshal (#%d): %v", i, err)
+			continue
+		}
+		if string(v.Raw.JSON) != tc.p {
+			t.Errorf("Unmarshal (#%d): unexpected raw json: %s", i, v.Raw.JSON)
+		}
+		v.Raw = RawFields{}
+		if !reflect.DeepEqual(v, tc.o) {
+			t.Errorf("Unmarshal (#%d):\n\tgot      %#v\n\texpected %#v", i, v, tc.o)
+		}
+	}
+}
+
+func TestUnmarshal_nested(t *testing.T) {
+	v := []*Product{}
+	p := `[{"productUid":"a","portSpeed":1000.0,"resources":{"interface":{"port_speed":"1000","up":1.0}},"associatedVxcs":[{"rateLimit":"100","aEnd":{"vlan":12.0},"resources":{"csp_connection":{"asn":65000.0,"Vif_id":"dxvif-1"}}}]}]`
+	if err := Unmarshal([]byte(p), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(v) != 1 || v[0].PortSpeed != 1000 || v[0].Resources.Interface.PortSpeed != 1000 || v[0].Resources.Interface.Up != 1 {
+		t.Fatalf("Unmarshal: unexpected product: %#v", v)
+	}
+	vxc := v[0].AssociatedVxcs[0]
+	if vxc.RateLimit != 100 || vxc.AEnd.Vlan != 12 || vxc.Resources.AwsVirtualInterface.Asn != 65000 || vxc.Resources.AwsVirtualInterface.VifId != "dxvif-1" {
+		t.Errorf("Unmarshal: unexpected associated vxc: %#v", vxc)
+	}
+}
+
+func TestUnmarshal_unknown(t *testing.T) {
+	v := []*Product{}
+	p := `[{"productUid":"a","newField":{"b":1},"resources":{"interface":{"up":1,"mtu":9000}},"aggregationId":null}]`
+	d := &decoder{}
+	if err := d.unmarshal([]byte(p), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(v[0].Raw.Unknown) != 1 || string(v[0].Raw.Unknown["newField"]) != `{"b":1}` {
+		t.Errorf("Unmarshal: unexpected unknown fields: %v", v[0].Raw.Unknown)
+	}
+	mtu := uint64(0)
+	if ok, err := v[0].Resources.Interface.Raw.Get("mtu", &mtu); !ok || err != nil || mtu != 9000 {
+		t.Errorf("RawFields.Get: got %d, %v, %v", mtu, ok, err)
+	}
+	if ok, _ := v[0].Raw.Get("missing", &mtu); ok {
+		t.Errorf("RawFields.Get: expected missing field not to be found")
+	}
+	err, ok := d.drift().(*SchemaDriftError)
+	if !ok {
+		t.Fatalf("drift: expected a *SchemaDriftError, got %#v", d.drift())
+	}
+	expected := []string{"Product[].Resources.Interface.mtu", "Product[].newField"}
+	if !reflect.DeepEqual(err.Fields, expected) {
+		t.Errorf("drift: got %v, expected %v", err.Fields, expected)
+	}
+}
+
+func TestUnmarshal_errors(t *testing.T) {
+	testCases := []struct {
+		p string
+		f string
+	}{
+		{ // 0
+			p: `[{"productUid":"a","portSpeed":1000.5}]`,
+			f: "Product[0].PortSpeed",
+		},
+		{ // 1
+			p: `[{"productUid":"a","portSpeed":-1}]`,
+			f: "Product[0].PortSpeed",
+		},
+		{ // 2
+			p: `[{},{"associatedVxcs":[{"aEnd":{"vlan":"foo"}}]}]`,
+			f: "Product[1].AssociatedVxcs[0].AEnd.Vlan",
+		},
+		{ // 3
+			p: `[{"resources":{"vll":{"a_vlan":true}}}]`,
+			f: "Product[0].Resources.VLL.AVLan",
+		},
+		{ // 4
+			p: `[{"productName":5}]`,
+			f: "Product[0].ProductName",
+		},
+	}
+	for i, tc := range testCases {
+		v := []*Product{}
+		err := Unmarshal([]byte(tc.p), &v)
+		de, ok := err.(*DecodeError)
+		if !ok {
+			t.Errorf("Unmarshal (#%d): expected a *DecodeError, got %#v", i, err)
+			continue
+		}
+		if de.Field != tc.f {
+			t.Errorf("Unmarshal (#%d): unexpected field: got %s, expected %s", i, de.Field, tc.f)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+)
+
+// Design is a set of planned products to estimate the cost of. Speeds and
+// rate limits are in Mbps.
+type Design struct {
+	Ports []*PortDesign
+	MCRs  []*MCRDesign
+	VXCs  []*VxcDesign
+	IXs   []*IxDesign
+}
+
+type PortDesign struct {
+	Name       string
+	LocationId uint64
+	Speed      uint64
+	Term       uint64
+	BuyoutPort bool
+}
+
+type MCRDesign struct {
+	Name       string
+	LocationId uint64
+	Speed      uint64
+}
+
+type VxcDesign struct {
+	Name        string
+	ALocationId uint64
+	BLocationId uint64
+	RateLimit   uint64
+}
+
+type IxDesign struct {
+	Name       string
+	IxType     string
+	LocationId uint64
+	RateLimit  uint64
+}
+
+// Estimate is the cost of a design. Every item is priced in the currency of
+// the account, so the totals can be summed.
+type Estimate struct {
+	Currency     string
+	Items        []*EstimateItem
+	MonthlyRate  float64
+	MonthlySetup float64
+}
+
+type EstimateItem struct {
+	Name         string
+	ProductType  string
+	Speed        uint64
+	MonthlyRate  float64
+	MonthlySetup float64
+	Charges      *MegaportCharges
+}
+
+// EstimateDesign prices every product of the design through the pricebook.
+// The monthly rate of an item is the MonthlyTotal of its charges.
+func (c *Client) EstimateDesign(d *Design) (*Estimate, error) {
+	e := &Estimate{Items: []*EstimateItem{}}
+	for _, p := range d.Ports {
+		ch, err := c.GetMegaportPrice(p.LocationId, p.Speed, p.Term, "", p.BuyoutPort)
+		if err != nil {
+			return nil, fmt.Errorf("cannot price port %q: %w", p.Name, err)
+		}
+		if err := e.add(p.Name, ProductTypePort, p.Speed, ch); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range d.MCRs {
+		ch, err := c.GetMCR2Price(m.LocationId, m.Speed, "")
+		if err != nil {
+			return nil, fmt.Errorf("cannot price mcr %q: %w", m.Name, err)
+		}
+		if err := e.add(m.Name, ProductTypeMCR2, m.Speed, ch); err != nil {
+			return nil, err
+		}
+	}
+	for _, v := range d.VXCs {
+		ch, err := c.GetVxcPrice(v.ALocationId, v.BLocationId, v.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot price vxc %q: %w", v.Name, err)
+		}
+		if err := e.add(v.Name, ProductTypeVXC, v.RateLimit, ch); err != nil {
+			return nil, err
+		}
+	}
+	for _, x := range d.IXs {
+		ch, err := c.GetIxPrice(x.IxType, x.LocationId, x.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot price ix %q: %w", x.Name, err)
+		}
+		if err := e.add(x.Name, ProductTypeIX, x.RateLimit, ch); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// MonthlyTotal returns the monthly rate of a product of the given speed (in
+// Mbps): its MonthlyRate plus its MbpsRate and LongHaulMbpsRate, which applies
+// to VXCs between distant locations, multiplied by its speed.
+// TODO: it is assumed that the MonthlyRate of the pricebook does not already
+// include the per-Mbps charges; this has not been checked against a recorded
+// pricebook response, and the fake api follows the same assumption.
+func (ch *MegaportCharges) MonthlyTotal(speed uint64) float64 {
+	return ch.MonthlyRate + (ch.MbpsRate+ch.LongHaulMbpsRate)*float64(speed)
+}
+
+func (e *Estimate) add(name, productType string, speed uint64, ch *MegaportCharges) error {
+	if e.Currency == "" {
+		e.Currency = ch.Currency
+	}
+	if ch.Currency != e.Currency {
+		return fmt.Errorf("cannot price %q in %s, other products are priced in %s", name, ch.Currency, e.Currency)
+	}
+	item := &EstimateItem{
+		Name:         name,
+		ProductType:  productType,
+		Speed:        speed,
+		MonthlyRate:  ch.MonthlyTotal(speed),
+		MonthlySetup: ch.MonthlySetup,
+		Charges:      ch,
+	}
+	e.Items = append(e.Items, item)
+	e.MonthlyRate += item.MonthlyRate
+	e.MonthlySetup += item.MonthlySetup
+	return nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+)
+
+func TestClient_EstimateDesign(t *testing.T) {
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/v2/pricebook/megaport":
+			if q.Get("locationId") != "1" || q.Get("speed") != "10000" || q.Get("term") != "12" || q.Get("buyoutPort") != "false" {
+				t.Errorf("TestClient_EstimateDesign: unexpected port query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"message":"","data":{"currency":"GBP","monthlyRate":900,"monthlySetup":0,"productType":"MEGAPORT"}}`)
+		case "/v2/pricebook/mcr2":
+			fmt.Fprint(w, `{"message":"","data":{"currency":"GBP","mbpsRate":0.2,"monthlySetup":100,"productType":"MCR2"}}`)
+		case "/v2/pricebook/vxc":
+			if q.Get("aLocationId") != "1" || q.Get("bLocationId") != "2" || q.Get("speed") != "200" {
+				t.Errorf("TestClient_EstimateDesign: unexpected vxc query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"message":"","data":{"currency":"GBP","mbpsRate":0.5,"longHaulMbpsRate":0.25,"productType":"VXC"}}`)
+		case "/v2/pricebook/ix":
+			if q.Get("ixType") != "LINX LON1" || q.Get("portLocationId") != "1" {
+				t.Errorf("TestClient_EstimateDesign: unexpected ix query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"message":"","data":{"currency":"GBP","monthlyRate":50,"mbpsRate":0.3,"productType":"IX"}}`)
+		}
+	})
+	defer s.Close()
+	e, err := c.EstimateDesign(&Design{
+		Ports: []*PortDesign{{Name: "port", LocationId: 1, Speed: 10000, Term: 12}},
+		MCRs:  []*MCRDesign{{Name: "mcr", LocationId: 1, Speed: 1000}},
+		VXCs:  []*VxcDesign{{Name: "vxc", ALocationId: 1, BLocationId: 2, RateLimit: 200}},
+		IXs:   []*IxDesign{{Name: "ix", IxType: "LINX LON1", LocationId: 1, RateLimit: 100}},
+	})
+	if err != nil {
+		t.Fatalf("TestClient_EstimateDesign: %v", err)
+	}
+	expected := []float64{900, 200, 150, 80}
+	for i, item := range e.Items {
+		if math.Abs(item.MonthlyRate-expected[i]) > 1e-9 {
+			t.Errorf("TestClient_EstimateDesign: unexpected monthly rate of %s: got %f, expected %f", item.Name, item.MonthlyRate, expected[i])
+		}
+	}
+	if e.Currency != "GBP" || len(e.Items) != 4 || math.Abs(e.MonthlyRate-1330) > 1e-9 || e.MonthlySetup != 100 {
+		t.Errorf("TestClient_EstimateDesign: unexpected estimate: %+v", e)
+	}
+}
+
+func TestClient_EstimateDesign_currencyMismatch(t *testing.T) {
+	currencies := []string{"GBP", "EUR"}
+	c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"","data":{"currency":%q,"monthlyRate":100}}`, currencies[0])
+		currencies = currencies[1:]
+	})
+	defer s.Close()
+	_, err := c.EstimateDesign(&Design{
+		Ports: []*PortDesign{{Name: "a", LocationId: 1, Speed: 1000, Term: 1}, {Name: "b", LocationId: 2, Speed: 1000, Term: 1}},
+	})
+	if err == nil {
+		t.Errorf("TestClient_EstimateDesign_currencyMismatch: expected an error")
+	}
+}
@@ -31,3 +31,11 @@ func Bool(v interface{}) *bool {
 	r := v.(bool)
 	return &r
 }
+
+func Float64(v interface{}) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := v.(float64)
+	return &r
+}
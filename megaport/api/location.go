@@ -0,0 +1,176 @@
+package api
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	LocationStatusActive     = "Active"
+	LocationStatusDeployment = "Deployment"
+
+	DiversityZoneRed  = "red"
+	DiversityZoneBlue = "blue"
+
+	earthRadiusKm = 6371.0
+)
+
+// LocationQuery selects locations. Fields that are nil are not used for
+// filtering, and string fields are matched case-insensitively. Port speeds are
+// in Mbps, as everywhere else in the api, even though locations list the
+// speeds of their ports in Gbps.
+type LocationQuery struct {
+	Name             *regexp.Regexp
+	Country          *string
+	Metro            *string
+	Market           *string
+	NetworkRegion    *string
+	Status           *string
+	VRouterAvailable *bool
+	PortSpeed        *uint64
+	MCR2Speed        *uint64
+
+	// When both are set, locations are sorted by their distance from this
+	// point, nearest first, and MaxDistance (in km) can limit the results.
+	Latitude    *float64
+	Longitude   *float64
+	MaxDistance *float64
+}
+
+// Match reports whether the location satisfies every filter of the query.
+func (q *LocationQuery) Match(l *Location) bool {
+	if q.Name != nil && !q.Name.MatchString(l.Name) {
+		return false
+	}
+	if !matchFold(q.Country, l.Country) || !matchFold(q.Metro, l.Metro) || !matchFold(q.Market, l.Market) ||
+		!matchFold(q.NetworkRegion, l.NetworkRegion) || !matchFold(q.Status, l.Status) {
+		return false
+	}
+	if q.VRouterAvailable != nil && *q.VRouterAvailable != l.VRouterAvailable {
+		return false
+	}
+	if q.PortSpeed != nil && !l.SupportsPortSpeed(*q.PortSpeed) {
+		return false
+	}
+	if q.MCR2Speed != nil && !l.SupportsMCR2Speed(*q.MCR2Speed) {
+		return false
+	}
+	if q.MaxDistance != nil && q.Latitude != nil && q.Longitude != nil && l.DistanceTo(*q.Latitude, *q.Longitude) > *q.MaxDistance {
+		return false
+	}
+	return true
+}
+
+// Filter returns the locations that match the query, sorted by distance when
+// the query has coordinates and by id otherwise.
+func (q *LocationQuery) Filter(ll []*Location) []*Location {
+	r := []*Location{}
+	for _, l := range ll {
+		if q.Match(l) {
+			r = append(r, l)
+		}
+	}
+	if q.Latitude != nil && q.Longitude != nil {
+		lat, lon := *q.Latitude, *q.Longitude
+		sort.SliceStable(r, func(i, j int) bool {
+			return r[i].DistanceTo(lat, lon) < r[j].DistanceTo(lat, lon)
+		})
+	} else {
+		sort.SliceStable(r, func(i, j int) bool {
+			return r[i].Id < r[j].Id
+		})
+	}
+	return r
+}
+
+func (c *Client) QueryLocations(q *LocationQuery) ([]*Location, error) {
+	ll, err := c.GetLocations()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(ll), nil
+}
+
+// SupportsPortSpeed reports whether ports of the given speed (in Mbps) can be
+// ordered at the location.
+func (l *Location) SupportsPortSpeed(speed uint64) bool {
+	if speed%1000 != 0 {
+		return false
+	}
+	for _, s := range l.Products.Megaport {
+		if s*1000 == speed {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsMCR2Speed reports whether an MCR2 of the given speed (in Mbps) can
+// be ordered at the location.
+func (l *Location) SupportsMCR2Speed(speed uint64) bool {
+	for _, s := range l.Products.MCR2 {
+		if s == speed {
+			return true
+		}
+	}
+	return false
+}
+
+// PortDiversityZones returns the diversity zones in which ports of the given
+// speed (in Mbps) can be ordered at the location.
+func (l *Location) PortDiversityZones(speed uint64) []string {
+	return l.DiversityZones.zones(speed, func(z *LocationDiversityZone) []uint64 { return z.MegaportSpeedMbps })
+}
+
+// MCR2DiversityZones returns the diversity zones in which an MCR2 of the given
+// speed (in Mbps) can be ordered at the location.
+func (l *Location) MCR2DiversityZones(speed uint64) []string {
+	return l.DiversityZones.zones(speed, func(z *LocationDiversityZone) []uint64 { return z.McrSpeedMbps })
+}
+
+// Known reports whether the location lists any diversity zones at all.
+func (dz LocationDiversityZones) Known() bool {
+	return dz.Red != nil || dz.Blue != nil
+}
+
+// Zones returns the zones of the location by name, in a stable order.
+func (dz LocationDiversityZones) Zones() ([]string, []*LocationDiversityZone) {
+	names, zones := []string{}, []*LocationDiversityZone{}
+	if dz.Red != nil {
+		names, zones = append(names, DiversityZoneRed), append(zones, dz.Red)
+	}
+	if dz.Blue != nil {
+		names, zones = append(names, DiversityZoneBlue), append(zones, dz.Blue)
+	}
+	return names, zones
+}
+
+func (dz LocationDiversityZones) zones(speed uint64, speeds func(*LocationDiversityZone) []uint64) []string {
+	r := []string{}
+	names, zones := dz.Zones()
+	for i, z := range zones {
+		for _, s := range speeds(z) {
+			if s == speed {
+				r = append(r, names[i])
+				break
+			}
+		}
+	}
+	return r
+}
+
+// DistanceTo returns the great-circle distance in km between the location and
+// the given coordinates.
+func (l *Location) DistanceTo(latitude, longitude float64) float64 {
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := rad(latitude - l.Latitude)
+	dLon := rad(longitude - l.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(l.Latitude))*math.Cos(rad(latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func matchFold(q *string, v string) bool {
+	return q == nil || strings.EqualFold(*q, v)
+}
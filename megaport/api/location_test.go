@@ -0,0 +1,121 @@
+package api
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func testLocations() []*Location {
+	return []*Location{
+		{Id: 3, Name: "Equinix SY1", Country: "Australia", Metro: "Sydney", Market: "AU", NetworkRegion: "MP1", Status: "Active", Latitude: -33.9213, Longitude: 151.1889, Products: LocationProducts{Megaport: []uint64{1, 10}}},
+		{Id: 45, Name: "Telehouse North", Country: "United Kingdom", Metro: "London", Market: "UK", NetworkRegion: "MP1", Status: "Active", VRouterAvailable: true, Latitude: 51.5113, Longitude: -0.0015, Products: LocationProducts{MCR2: []uint64{1000, 2500, 5000, 10000}, Megaport: []uint64{1, 10, 100}}},
+		{Id: 59, Name: "Equinix FR5", Country: "Germany", Metro: "Frankfurt", Market: "DE", NetworkRegion: "MP1", Status: "Active", VRouterAvailable: true, Latitude: 50.1111, Longitude: 8.7318, Products: LocationProducts{MCR2: []uint64{1000, 2500, 5000, 10000}, Megaport: []uint64{1, 10, 100}}},
+		{Id: 67, Name: "Equinix LD5", Country: "United Kingdom", Metro: "London", Market: "UK", NetworkRegion: "MP1", Status: "Active", VRouterAvailable: true, Latitude: 51.5226, Longitude: -0.6336, Products: LocationProducts{MCR2: []uint64{1000, 2500, 5000}, Megaport: []uint64{1, 10}}},
+		{Id: 112, Name: "Digital Realty LHR20", Country: "United Kingdom", Metro: "London", Market: "UK", NetworkRegion: "MP1", Status: "Deployment", Latitude: 51.5008, Longitude: -0.0178, Products: LocationProducts{Megaport: []uint64{1, 10, 100}}},
+	}
+}
+
+func TestLocationQuery_Filter(t *testing.T) {
+	testCases := []struct {
+		q   LocationQuery
+		ids []uint64
+	}{
+		{ // 0
+			q:   LocationQuery{},
+			ids: []uint64{3, 45, 59, 67, 112},
+		},
+		{ // 1
+			q:   LocationQuery{Metro: String("london"), Status: String(LocationStatusActive)},
+			ids: []uint64{45, 67},
+		},
+		{ // 2
+			q:   LocationQuery{PortSpeed: Uint64(uint64(100000))},
+			ids: []uint64{45, 59, 112},
+		},
+		{ // 3
+			q:   LocationQuery{MCR2Speed: Uint64(uint64(10000)), Market: String("UK")},
+			ids: []uint64{45},
+		},
+		{ // 4
+			q:   LocationQuery{VRouterAvailable: Bool(false)},
+			ids: []uint64{3, 112},
+		},
+		{ // 5
+			q:   LocationQuery{Country: String("Germany"), NetworkRegion: String("MP1"), Name: regexp.MustCompile("FR5")},
+			ids: []uint64{59},
+		},
+		{ // 6: nearest live location with 100G ports to Slough
+			q:   LocationQuery{Status: String(LocationStatusActive), PortSpeed: Uint64(uint64(100000)), Latitude: Float64(51.51), Longitude: Float64(-0.59)},
+			ids: []uint64{45, 59},
+		},
+		{ // 7
+			q:   LocationQuery{Latitude: Float64(51.51), Longitude: Float64(-0.59), MaxDistance: Float64(50.0)},
+			ids: []uint64{67, 112, 45},
+		},
+		{ // 8
+			q:   LocationQuery{PortSpeed: Uint64(uint64(1500))},
+			ids: []uint64{},
+		},
+	}
+	for i, tc := range testCases {
+		ids := []uint64{}
+		for _, l := range tc.q.Filter(testLocations()) {
+			ids = append(ids, l.Id)
+		}
+		if !reflect.DeepEqual(ids, tc.ids) {
+			t.Errorf("LocationQuery.Filter (#%d): got %v, expected %v", i, ids, tc.ids)
+		}
+	}
+}
+
+func TestLocation_DistanceTo(t *testing.T) {
+	l := testLocations()[1]
+	if d := l.DistanceTo(l.Latitude, l.Longitude); d != 0 {
+		t.Errorf("Location.DistanceTo: unexpected distance to itself: %f", d)
+	}
+	if d := l.DistanceTo(50.1111, 8.7318); math.Abs(d-637) > 5 {
+		t.Errorf("Location.DistanceTo: unexpected distance from London to Frankfurt: %f", d)
+	}
+	if d := l.DistanceTo(-33.9213, 151.1889); math.Abs(d-16990) > 50 {
+		t.Errorf("Location.DistanceTo: unexpected distance from London to Sydney: %f", d)
+	}
+}
+
+func TestLocation_PortDiversityZones(t *testing.T) {
+	l := &Location{DiversityZones: LocationDiversityZones{
+		Red:  &LocationDiversityZone{McrSpeedMbps: []uint64{1000, 2500}, MegaportSpeedMbps: []uint64{1000, 10000, 100000}},
+		Blue: &LocationDiversityZone{MegaportSpeedMbps: []uint64{1000, 10000}},
+	}}
+	for i, tc := range []struct {
+		zones    []string
+		expected []string
+	}{
+		{l.PortDiversityZones(1000), []string{DiversityZoneRed, DiversityZoneBlue}},
+		{l.PortDiversityZones(100000), []string{DiversityZoneRed}},
+		{l.PortDiversityZones(40000), []string{}},
+		{l.MCR2DiversityZones(2500), []string{DiversityZoneRed}},
+		{l.MCR2DiversityZones(10000), []string{}},
+	} {
+		if !reflect.DeepEqual(tc.zones, tc.expected) {
+			t.Errorf("Location.PortDiversityZones (#%d): expected %v, got %v", i, tc.expected, tc.zones)
+		}
+	}
+}
+
+func TestLocation_decodeDiversityZones(t *testing.T) {
+	l := &Location{}
+	if err := Unmarshal([]byte(`{"id":45,"diversityZones":{"red":{"mcr_speed_mbps":[1000,2500],"megaport_speed_mbps":[1000,10000],"mve_available":true,"mve_max_cpu_core_count":12}}}`), l); err != nil {
+		t.Fatalf("TestLocation_decodeDiversityZones: %v", err)
+	}
+	if !l.DiversityZones.Known() || l.DiversityZones.Blue != nil {
+		t.Fatalf("TestLocation_decodeDiversityZones: unexpected zones: %#v", l.DiversityZones)
+	}
+	if zones := l.PortDiversityZones(10000); !reflect.DeepEqual(zones, []string{DiversityZoneRed}) {
+		t.Errorf("TestLocation_decodeDiversityZones: unexpected port zones: %v", zones)
+	}
+	if zones := l.MCR2DiversityZones(2500); !reflect.DeepEqual(zones, []string{DiversityZoneRed}) {
+		t.Errorf("TestLocation_decodeDiversityZones: unexpected MCR2 zones: %v", zones)
+	}
+}
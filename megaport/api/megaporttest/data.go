@@ -0,0 +1,354 @@
+package megaporttest
+
+import (
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+const (
+	LocationTelehouseNorth = 45
+	LocationEquinixLD5     = 67
+	LocationEquinixFR5     = 59
+	LocationEquinixSY1     = 3
+	LocationDigitalRealty  = 112
+
+	companyUidAWS = "9ea6ad7e-5f22-4f94-9d4e-9b6c2f2c8c1a"
+)
+
+func defaultLocations() []*api.Location {
+	return []*api.Location{
+		{
+			Address: api.LocationAddress{
+				City:     "London",
+				Country:  "United Kingdom",
+				Postcode: "E14 2AA",
+				State:    "London",
+				Street:   "Coriander Avenue",
+				Suburb:   "Docklands",
+			},
+			Country: "United Kingdom",
+			DiversityZones: api.LocationDiversityZones{
+				Red:  &api.LocationDiversityZone{McrSpeedMbps: []uint64{1000, 2500, 5000, 10000}, MegaportSpeedMbps: []uint64{1000, 10000, 100000}},
+				Blue: &api.LocationDiversityZone{McrSpeedMbps: []uint64{1000, 2500, 5000, 10000}, MegaportSpeedMbps: []uint64{1000, 10000}},
+			},
+			Id:               LocationTelehouseNorth,
+			Latitude:         51.5113,
+			LiveDate:         1467201600000,
+			Longitude:        -0.0015,
+			Market:           "UK",
+			Metro:            "London",
+			Name:             "Telehouse North",
+			NetworkRegion:    "MP1",
+			Products:         api.LocationProducts{MCR: true, MCRVersion: 2, MCR2: []uint64{1000, 2500, 5000, 10000}, Megaport: []uint64{1, 10, 100}},
+			SiteCode:         "lon-thn",
+			Status:           "Active",
+			VRouterAvailable: true,
+		},
+		{
+			Address: api.LocationAddress{
+				City:     "Slough",
+				Country:  "United Kingdom",
+				Postcode: "SL2 5HQ",
+				State:    "Berkshire",
+				Street:   "8 Buckingham Avenue",
+				Suburb:   "Slough Trading Estate",
+			},
+			Country: "United Kingdom",
+			DiversityZones: api.LocationDiversityZones{
+				Red:  &api.LocationDiversityZone{McrSpeedMbps: []uint64{1000, 2500, 5000}, MegaportSpeedMbps: []uint64{1000, 10000}},
+				Blue: &api.LocationDiversityZone{MegaportSpeedMbps: []uint64{1000, 10000}},
+			},
+			Id:               LocationEquinixLD5,
+			Latitude:         51.5226,
+			LiveDate:         1451606400000,
+			Longitude:        -0.6336,
+			Market:           "UK",
+			Metro:            "London",
+			Name:             "Equinix LD5",
+			NetworkRegion:    "MP1",
+			Products:         api.LocationProducts{MCR: true, MCRVersion: 2, MCR2: []uint64{1000, 2500, 5000}, Megaport: []uint64{1, 10}},
+			SiteCode:         "lon-ld5",
+			Status:           "Active",
+			VRouterAvailable: true,
+		},
+		{
+			Address: api.LocationAddress{
+				City:     "Frankfurt",
+				Country:  "Germany",
+				Postcode: "60314",
+				State:    "Hessen",
+				Street:   "Larchenstrasse 110",
+				Suburb:   "Ostend",
+			},
+			Country: "Germany",
+			DiversityZones: api.LocationDiversityZones{
+				Red: &api.LocationDiversityZone{MegaportSpeedMbps: []uint64{1000, 10000, 100000}},
+			},
+			Id:               LocationEquinixFR5,
+			Latitude:         50.1111,
+			LiveDate:         1483228800000,
+			Longitude:        8.7318,
+			Market:           "DE",
+			Metro:            "Frankfurt",
+			Name:             "Equinix FR5",
+			NetworkRegion:    "MP1",
+			Products:         api.LocationProducts{MCR: true, MCRVersion: 2, MCR2: []uint64{1000, 2500, 5000, 10000}, Megaport: []uint64{1, 10, 100}},
+			SiteCode:         "fra-fr5",
+			Status:           "Active",
+			VRouterAvailable: true,
+		},
+		{
+			Address: api.LocationAddress{
+				City:     "Sydney",
+				Country:  "Australia",
+				Postcode: "2017",
+				State:    "NSW",
+				Street:   "639 Gardeners Road",
+				Suburb:   "Mascot",
+			},
+			Country:          "Australia",
+			Id:               LocationEquinixSY1,
+			Latitude:         -33.9213,
+			LiveDate:         1388534400000,
+			Longitude:        151.1889,
+			Market:           "AU",
+			Metro:            "Sydney",
+			Name:             "Equinix SY1",
+			NetworkRegion:    "MP1",
+			Products:         api.LocationProducts{MCR: false, Megaport: []uint64{1, 10}},
+			SiteCode:         "syd-sy1",
+			Status:           "Active",
+			VRouterAvailable: false,
+		},
+		{
+			Address: api.LocationAddress{
+				City:     "London",
+				Country:  "United Kingdom",
+				Postcode: "E14 9YY",
+				State:    "London",
+				Street:   "Sovereign House, 227 Marsh Wall",
+				Suburb:   "Docklands",
+			},
+			Country:          "United Kingdom",
+			Id:               LocationDigitalRealty,
+			Latitude:         51.5008,
+			Longitude:        -0.0178,
+			Market:           "UK",
+			Metro:            "London",
+			Name:             "Digital Realty LHR20",
+			NetworkRegion:    "MP1",
+			Products:         api.LocationProducts{Megaport: []uint64{1, 10, 100}},
+			SiteCode:         "lon-lhr20",
+			Status:           "Deployment",
+			VRouterAvailable: false,
+		},
+	}
+}
+
+func defaultPartnerPorts() []*api.Megaport {
+	return []*api.Megaport{
+		{
+			CompanyName:   "AWS",
+			CompanyUid:    companyUidAWS,
+			ConnectType:   "AWS",
+			DiversityZone: api.DiversityZoneRed,
+			LocationId:    LocationEquinixLD5,
+			ProductUid:    "4e1b3a8f-2f86-4b3c-9f9c-0d2b6b1a7f01",
+			Rank:          1,
+			Speed:         10000,
+			Title:         "Amazon Web Services (eu-west-1) [DZ-RED]",
+			VxcPermitted:  true,
+		},
+		{
+			CompanyName:   "AWS",
+			CompanyUid:    companyUidAWS,
+			ConnectType:   "AWS",
+			DiversityZone: api.DiversityZoneBlue,
+			LocationId:    LocationEquinixLD5,
+			ProductUid:    "4e1b3a8f-2f86-4b3c-9f9c-0d2b6b1a7f02",
+			Rank:          2,
+			Speed:         10000,
+			Title:         "Amazon Web Services (eu-west-1) [DZ-BLUE]",
+			VxcPermitted:  false,
+		},
+		{
+			CompanyName:   "AWS",
+			CompanyUid:    companyUidAWS,
+			ConnectType:   "AWS",
+			DiversityZone: api.DiversityZoneRed,
+			LocationId:    LocationTelehouseNorth,
+			ProductUid:    "4e1b3a8f-2f86-4b3c-9f9c-0d2b6b1a7f03",
+			Rank:          1,
+			Speed:         10000,
+			Title:         "Amazon Web Services (eu-west-2) [DZ-RED]",
+			VxcPermitted:  true,
+		},
+		{
+			CompanyName:   "AWS",
+			CompanyUid:    companyUidAWS,
+			ConnectType:   "AWS",
+			DiversityZone: api.DiversityZoneBlue,
+			LocationId:    LocationTelehouseNorth,
+			ProductUid:    "4e1b3a8f-2f86-4b3c-9f9c-0d2b6b1a7f05",
+			Rank:          2,
+			Speed:         10000,
+			Title:         "Amazon Web Services (eu-west-2) [DZ-BLUE]",
+			VxcPermitted:  true,
+		},
+		{
+			CompanyName:   "AWS",
+			CompanyUid:    companyUidAWS,
+			ConnectType:   "AWS",
+			DiversityZone: api.DiversityZoneRed,
+			LocationId:    LocationEquinixFR5,
+			ProductUid:    "4e1b3a8f-2f86-4b3c-9f9c-0d2b6b1a7f04",
+			Rank:          1,
+			Speed:         1000,
+			Title:         "Amazon Web Services (eu-central-1) [DZ-RED]",
+			VxcPermitted:  true,
+		},
+	}
+}
+
+func defaultInternetExchanges() map[uint64][]*api.InternetExchange {
+	ipv4 := func(v string) api.InternetExchangeIPAddress {
+		return api.InternetExchangeIPAddress{Type: "ipv4", Value: v}
+	}
+	ipv6 := func(v string) api.InternetExchangeIPAddress {
+		return api.InternetExchangeIPAddress{Type: "ipv6", Value: v}
+	}
+	linx := []*api.InternetExchange{
+		{
+			ASN:           8714,
+			Description:   "LINX LON1 peering LAN",
+			GroupMetro:    "London",
+			Name:          "London IX (LINX LON1)",
+			NetworkRegion: "MP1",
+			PrimaryIPv4:   ipv4("195.66.224.0/22"),
+			PrimaryIPv6:   ipv6("2001:7f8:4::/64"),
+			SecondaryIPv4: ipv4("195.66.236.0/22"),
+			SecondaryIPv6: ipv6("2001:7f8:4:1::/64"),
+			State:         "Active",
+		},
+		{
+			ASN:           8714,
+			Description:   "LINX LON2 peering LAN",
+			GroupMetro:    "London",
+			Name:          "London IX (LINX LON2)",
+			NetworkRegion: "MP1",
+			PrimaryIPv4:   ipv4("195.66.236.0/22"),
+			PrimaryIPv6:   ipv6("2001:7f8:4:1::/64"),
+			State:         "Active",
+		},
+	}
+	return map[uint64][]*api.InternetExchange{
+		LocationTelehouseNorth: linx,
+		LocationEquinixLD5:     linx,
+		LocationEquinixFR5: {
+			{
+				ASN:           6695,
+				Description:   "DE-CIX Frankfurt peering LAN",
+				ECIX:          true,
+				GroupMetro:    "Frankfurt",
+				Name:          "Frankfurt IX (DE-CIX)",
+				NetworkRegion: "MP1",
+				PrimaryIPv4:   ipv4("80.81.192.0/21"),
+				PrimaryIPv6:   ipv6("2001:7f8::/64"),
+				State:         "Active",
+			},
+		},
+	}
+}
+
+func internetExchangeJSON(ix *api.InternetExchange) map[string]interface{} {
+	ip := func(v api.InternetExchangeIPAddress) interface{} {
+		if v.Value == "" {
+			return nil
+		}
+		return map[string]interface{}{"type": v.Type, "value": v.Value}
+	}
+	return map[string]interface{}{
+		"asn":            ix.ASN,
+		"description":    ix.Description,
+		"ecix":           ix.ECIX,
+		"group_metro":    ix.GroupMetro,
+		"name":           ix.Name,
+		"network_region": ix.NetworkRegion,
+		"primaryIPv4":    ip(ix.PrimaryIPv4),
+		"primaryIPv6":    ip(ix.PrimaryIPv6),
+		"secondaryIPv4":  ip(ix.SecondaryIPv4),
+		"secondaryIPv6":  ip(ix.SecondaryIPv6),
+		"state":          ix.State,
+	}
+}
+
+func locationJSON(l *api.Location) map[string]interface{} {
+	ret := map[string]interface{}{
+		"address": map[string]interface{}{
+			"city":     l.Address.City,
+			"country":  l.Address.Country,
+			"postcode": l.Address.Postcode,
+			"state":    l.Address.State,
+			"street":   l.Address.Street,
+			"suburb":   l.Address.Suburb,
+		},
+		"campus":        l.Campus,
+		"country":       l.Country,
+		"id":            l.Id,
+		"latitude":      l.Latitude,
+		"liveDate":      l.LiveDate,
+		"longitude":     l.Longitude,
+		"market":        l.Market,
+		"metro":         l.Metro,
+		"name":          l.Name,
+		"networkRegion": l.NetworkRegion,
+		"products": map[string]interface{}{
+			"mcr":        l.Products.MCR,
+			"mcrVersion": l.Products.MCRVersion,
+			"mcr1":       uint64List(l.Products.MCR1),
+			"mcr2":       uint64List(l.Products.MCR2),
+			"megaport":   uint64List(l.Products.Megaport),
+		},
+		"siteCode":         l.SiteCode,
+		"status":           l.Status,
+		"vRouterAvailable": l.VRouterAvailable,
+	}
+	if l.DiversityZones.Known() {
+		zones := map[string]interface{}{}
+		names, zz := l.DiversityZones.Zones()
+		for i, z := range zz {
+			zones[names[i]] = map[string]interface{}{
+				"mcr_speed_mbps":         uint64List(z.McrSpeedMbps),
+				"megaport_speed_mbps":    uint64List(z.MegaportSpeedMbps),
+				"mve_available":          z.MveAvailable,
+				"mve_max_cpu_core_count": z.MveMaxCpuCoreCount,
+			}
+		}
+		ret["diversityZones"] = zones
+	}
+	return ret
+}
+
+func partnerPortJSON(p *api.Megaport) map[string]interface{} {
+	return map[string]interface{}{
+		"aggregation_id": p.AggregationId,
+		"companyName":    p.CompanyName,
+		"companyUid":     p.CompanyUid,
+		"connectType":    p.ConnectType,
+		"diversityZone":  p.DiversityZone,
+		"lag_id":         p.LagId,
+		"lag_primary":    p.LagPrimary,
+		"locationId":     p.LocationId,
+		"productUid":     p.ProductUid,
+		"rank":           p.Rank,
+		"speed":          p.Speed,
+		"title":          p.Title,
+		"vxcPermitted":   p.VxcPermitted,
+	}
+}
+
+func uint64List(v []uint64) []uint64 {
+	if v == nil {
+		return []uint64{}
+	}
+	return v
+}
@@ -0,0 +1,128 @@
+package megaporttest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+const (
+	Currency = "USD"
+)
+
+var (
+	termDiscount = map[uint64]float64{1: 1, 12: 0.9, 24: 0.85, 36: 0.8}
+)
+
+// handlePricebook quotes made up but predictable prices: ports cost 100 per
+// Gbps per month, discounted for longer terms, with a setup fee of 500 for
+// 1-month terms. MCR1s and MCR2s cost 0.2 per Mbps. VXCs cost 0.5 per Mbps, plus a long
+// haul rate of 0.25 per Mbps between markets. IXs cost 50 plus 0.3 per Mbps.
+func (s *Server) handlePricebook(w http.ResponseWriter, r *http.Request, product string) {
+	q := r.URL.Query()
+	uintParam := func(name string) (uint64, bool) {
+		v, err := strconv.ParseUint(q.Get(name), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s: %q", name, q.Get(name)), nil)
+			return 0, false
+		}
+		return v, true
+	}
+	locationParam := func(name string) (*api.Location, bool) {
+		id, ok := uintParam(name)
+		if !ok {
+			return nil, false
+		}
+		l := s.location(id)
+		if l == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Could not find location %d", id), nil)
+			return nil, false
+		}
+		return l, true
+	}
+	speed, ok := uintParam("speed")
+	if !ok {
+		return
+	}
+	ch := &api.MegaportCharges{Currency: Currency}
+	switch product {
+	case "megaport":
+		l, ok := locationParam("locationId")
+		if !ok {
+			return
+		}
+		term, ok := uintParam("term")
+		if !ok {
+			return
+		}
+		if _, ok := termDiscount[term]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid term: %d", term), nil)
+			return
+		}
+		if !l.SupportsPortSpeed(speed) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Port speed %d is not available at %s", speed, l.Name), nil)
+			return
+		}
+		ch.ProductType = api.ProductTypePort
+		ch.MonthlyRate = float64(speed) / 1000 * 100 * termDiscount[term]
+		if term == 1 {
+			ch.MonthlySetup = 500
+		}
+	case "mcr", "mcr2":
+		if _, ok := locationParam("locationId"); !ok {
+			return
+		}
+		ch.ProductType = api.ProductTypeMCR2
+		if product == "mcr" {
+			ch.ProductType = api.ProductTypeMCR1
+		}
+		ch.MbpsRate = 0.2
+	case "vxc":
+		a, ok := locationParam("aLocationId")
+		if !ok {
+			return
+		}
+		b, ok := locationParam("bLocationId")
+		if !ok {
+			return
+		}
+		ch.ProductType = api.ProductTypeVXC
+		ch.MbpsRate = 0.5
+		if a.Market != b.Market {
+			ch.LongHaulMbpsRate = 0.25
+		}
+	case "ix":
+		if _, ok := locationParam("portLocationId"); !ok {
+			return
+		}
+		if q.Get("ixType") == "" {
+			writeError(w, http.StatusBadRequest, "Missing ixType", nil)
+			return
+		}
+		ch.ProductType = api.ProductTypeIX
+		ch.MonthlyRate = 50
+		ch.MbpsRate = 0.3
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cannot price %s", product), nil)
+		return
+	}
+	writeData(w, "", map[string]interface{}{
+		"currency":             ch.Currency,
+		"dailyRate":            ch.MonthlyRate * 12 / 365,
+		"dailySetup":           float64(0),
+		"empty":                false,
+		"fixedRecurringCharge": float64(0),
+		"forceProductChange":   nil,
+		"hourlyRate":           ch.MonthlyRate * 12 / 365 / 24,
+		"hourlySetup":          float64(0),
+		"key":                  "no key",
+		"longHaulMbpsRate":     ch.LongHaulMbpsRate,
+		"mbpsRate":             ch.MbpsRate,
+		"monthlyRate":          ch.MonthlyRate,
+		"monthlySetup":         ch.MonthlySetup,
+		"postPaidBaseRate":     "no base rate",
+		"productType":          ch.ProductType,
+	})
+}
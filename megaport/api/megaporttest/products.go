@@ -0,0 +1,806 @@
+package megaporttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+var (
+	nextProvisioningStatus = map[api.ProvisioningStatus]api.ProvisioningStatus{
+		api.ProvisioningStatusDeployable:      api.ProvisioningStatusConfigured,
+		api.ProvisioningStatusConfigured:      api.ProvisioningStatusLive,
+		api.ProvisioningStatusDecommissioning: api.ProvisioningStatusDecommissioned,
+	}
+	validTerms = map[uint64]bool{1: true, 12: true, 24: true, 36: true}
+	// connectTypeRateLimits is kept apart from api.ConnectTypeRateLimits so
+	// that the checks of the provider are tested against the server's own.
+	connectTypeRateLimits = map[string][]uint64{
+		"AWSHC":  {50, 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000},
+		"AZURE":  {50, 100, 200, 500, 1000, 2000, 5000, 10000},
+		"GOOGLE": {50, 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000, 20000, 50000},
+	}
+)
+
+type product struct {
+	uid         string
+	name        string
+	productType string
+	status      api.ProvisioningStatus
+	createDate  int64
+	costCentre  string
+	companyUid  string
+	companyName string
+
+	// ports
+	locationId            uint64
+	speed                 uint64
+	term                  uint64
+	marketplaceVisibility bool
+	diversityZone         string
+	virtual               bool
+
+	// vxcs
+	rateLimit     uint64
+	aEnd          vxcEnd
+	bEnd          vxcEnd
+	cspConnection map[string]interface{}
+	speedChange   *speedChange
+}
+
+// vxcEnd is one end of a VXC. VXCs to partner ports end at a product of
+// their own, like in the api, which keeps the uid of the partner port that
+// was ordered.
+type vxcEnd struct {
+	productUid     string
+	partnerPortUid string
+	vlan           uint64
+}
+
+type speedChange struct {
+	uid      string
+	newSpeed uint64
+}
+
+type activityLog struct {
+	createDate  int64
+	logType     string
+	message     string
+	productName string
+	productType string
+	productUid  string
+}
+
+type order struct {
+	ProductUid            string
+	ProductType           string
+	ProductName           string
+	LocationId            uint64
+	PortSpeed             uint64
+	Term                  uint64
+	CostCentre            string
+	DiversityZone         string
+	Virtual               bool
+	MarketplaceVisibility bool
+	AssociatedVxcs        []*vxcOrder
+}
+
+type vxcOrder struct {
+	ProductName    string
+	RateLimit      uint64
+	CostCentre     string
+	AEnd           *vxcOrderEnd
+	BEnd           *vxcOrderEnd
+	PartnerConfigs map[string]interface{}
+}
+
+type vxcOrderEnd struct {
+	ProductUid string
+	Vlan       uint64
+}
+
+type productUpdate struct {
+	Name                  *string
+	CostCentre            *string
+	MarketplaceVisibility *bool
+	RateLimit             *uint64
+	AEndVlan              *uint64
+	BEndVlan              *uint64
+}
+
+// AddPort adds a live port to the server and returns its uid. Ports that are
+// owned by a company other than the server's can be used as the B-End of
+// private VXCs, which then require approval for speed changes. MCRs can be
+// added by setting the product type, and Virtual for MCR1s.
+func (s *Server) AddPort(p *api.Product) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	np := &product{
+		uid:         p.ProductUid,
+		name:        p.ProductName,
+		productType: p.ProductType,
+		status:      p.ProvisioningStatus,
+		createDate:  timestamp(),
+		costCentre:  p.CostCentre,
+		companyUid:  p.CompanyUid,
+		companyName: p.CompanyName,
+		locationId:  p.LocationId,
+		speed:       p.PortSpeed,
+		term:        p.ContractTermMonths,
+		virtual:     p.Virtual,
+	}
+	if np.productType == "" {
+		np.productType = api.ProductTypePort
+	}
+	if np.uid == "" {
+		np.uid = uuid.New().String()
+	}
+	if np.status == "" {
+		np.status = api.ProvisioningStatusLive
+	}
+	if np.companyUid == "" {
+		np.companyUid = s.CompanyUid
+		np.companyName = s.CompanyName
+	}
+	s.addProduct(np)
+	return np.uid
+}
+
+// SetProvisioningStatus overrides the provisioning status of a product.
+func (s *Server) SetProvisioningStatus(uid string, status api.ProvisioningStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.products[uid]
+	if !ok {
+		return api.ErrNotFound
+	}
+	p.status = status
+	return nil
+}
+
+// ApproveSpeedChange applies the pending speed change of a VXC, as if the
+// B-End owner had approved it.
+func (s *Server) ApproveSpeedChange(uid string) error {
+	return s.resolveSpeedChange(uid, true)
+}
+
+// RejectSpeedChange discards the pending speed change of a VXC, as if the
+// B-End owner had rejected it.
+func (s *Server) RejectSpeedChange(uid string) error {
+	return s.resolveSpeedChange(uid, false)
+}
+
+func (s *Server) resolveSpeedChange(uid string, approve bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.products[uid]
+	if !ok {
+		return api.ErrNotFound
+	}
+	if p.speedChange == nil {
+		return fmt.Errorf("megaporttest: %s has no pending speed change", uid)
+	}
+	if approve {
+		p.rateLimit = p.speedChange.newSpeed
+		s.log(p, "SPEED_CHANGE", fmt.Sprintf("Speed change to %d Mbps approved", p.speedChange.newSpeed))
+	} else {
+		s.log(p, "SPEED_CHANGE", fmt.Sprintf("Speed change to %d Mbps rejected", p.speedChange.newSpeed))
+	}
+	p.speedChange = nil
+	return nil
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	orders := []*order{}
+	if err := json.NewDecoder(r.Body).Decode(&orders); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse the network design", err.Error())
+		return
+	}
+	companyUid, _ := s.requestCompany(r)
+	if errs := s.validateOrders(companyUid, orders); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, "Validation failed", errs)
+		return
+	}
+	writeData(w, "Validation passed", []interface{}{})
+}
+
+func (s *Server) handleBuy(w http.ResponseWriter, r *http.Request) {
+	orders := []*order{}
+	if err := json.NewDecoder(r.Body).Decode(&orders); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse the network design", err.Error())
+		return
+	}
+	companyUid, companyName := s.requestCompany(r)
+	if errs := s.validateOrders(companyUid, orders); len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, "Validation failed", errs)
+		return
+	}
+	data := []interface{}{}
+	for _, o := range orders {
+		if o.ProductUid == "" {
+			p := &product{
+				uid:                   uuid.New().String(),
+				name:                  o.ProductName,
+				productType:           api.ProductTypePort,
+				status:                api.ProvisioningStatusDeployable,
+				createDate:            timestamp(),
+				costCentre:            o.CostCentre,
+				companyUid:            companyUid,
+				companyName:           companyName,
+				locationId:            o.LocationId,
+				speed:                 o.PortSpeed,
+				term:                  o.Term,
+				marketplaceVisibility: o.MarketplaceVisibility,
+				diversityZone:         o.DiversityZone,
+			}
+			if p.diversityZone == "" {
+				if zones := s.location(o.LocationId).PortDiversityZones(o.PortSpeed); len(zones) > 0 {
+					p.diversityZone = zones[0]
+				}
+			}
+			s.addProduct(p)
+			data = append(data, map[string]interface{}{
+				"productType":         p.productType,
+				"technicalServiceUid": p.uid,
+			})
+			continue
+		}
+		for _, vo := range o.AssociatedVxcs {
+			p := &product{
+				uid:         uuid.New().String(),
+				name:        vo.ProductName,
+				productType: api.ProductTypeVXC,
+				status:      api.ProvisioningStatusDeployable,
+				createDate:  timestamp(),
+				costCentre:  vo.CostCentre,
+				companyUid:  companyUid,
+				companyName: companyName,
+				rateLimit:   vo.RateLimit,
+				aEnd:        vxcEnd{productUid: o.ProductUid},
+			}
+			if vo.AEnd != nil && vo.AEnd.Vlan != 0 {
+				p.aEnd.vlan = vo.AEnd.Vlan
+			} else {
+				p.aEnd.vlan = s.freeVlan(o.ProductUid)
+			}
+			p.bEnd.productUid = vo.BEnd.ProductUid
+			if pp := s.partnerPort(vo.BEnd.ProductUid); pp != nil {
+				p.bEnd.productUid = uuid.New().String()
+				p.bEnd.partnerPortUid = pp.ProductUid
+				p.locationId = pp.LocationId
+				p.cspConnection = cspConnectionJSON(pp, vo.PartnerConfigs, p.aEnd.vlan)
+			} else if vo.BEnd.Vlan != 0 {
+				p.bEnd.vlan = vo.BEnd.Vlan
+			} else {
+				p.bEnd.vlan = s.freeVlan(vo.BEnd.ProductUid)
+			}
+			s.addProduct(p)
+			data = append(data, map[string]interface{}{
+				"productType":             p.productType,
+				"vxcJTechnicalServiceUid": p.uid,
+			})
+		}
+	}
+	writeData(w, "Your order has been placed", data)
+}
+
+func (s *Server) validateOrders(companyUid string, orders []*order) []string {
+	errs := []string{}
+	if len(orders) == 0 {
+		return []string{"The network design is empty"}
+	}
+	for i, o := range orders {
+		if o.ProductUid == "" {
+			errs = append(errs, s.validatePortOrder(i, o)...)
+			continue
+		}
+		a, ok := s.products[o.ProductUid]
+		if !ok || a.companyUid != companyUid || a.productType != api.ProductTypePort || a.status.IsTerminal() {
+			errs = append(errs, fmt.Sprintf("[%d] product %s cannot be used as an A-End", i, o.ProductUid))
+			continue
+		}
+		if len(o.AssociatedVxcs) == 0 {
+			errs = append(errs, fmt.Sprintf("[%d] no services were ordered on %s", i, o.ProductUid))
+		}
+		for j, vo := range o.AssociatedVxcs {
+			errs = append(errs, s.validateVxcOrder(fmt.Sprintf("[%d].associatedVxcs[%d]", i, j), a, vo)...)
+		}
+	}
+	return errs
+}
+
+func (s *Server) validatePortOrder(i int, o *order) []string {
+	errs := []string{}
+	if o.ProductType != api.ProductTypePort {
+		errs = append(errs, fmt.Sprintf("[%d] unsupported product type %q", i, o.ProductType))
+	}
+	if o.ProductName == "" {
+		errs = append(errs, fmt.Sprintf("[%d] productName is required", i))
+	}
+	if !validTerms[o.Term] {
+		errs = append(errs, fmt.Sprintf("[%d] term %d is not valid", i, o.Term))
+	}
+	l := s.location(o.LocationId)
+	if l == nil {
+		return append(errs, fmt.Sprintf("[%d] location %d does not exist", i, o.LocationId))
+	}
+	if l.Status != api.LocationStatusActive {
+		errs = append(errs, fmt.Sprintf("[%d] location %s is not live", i, l.Name))
+	}
+	offered := false
+	for _, speed := range l.Products.Megaport {
+		if speed*1000 == o.PortSpeed {
+			offered = true
+		}
+	}
+	if !offered {
+		errs = append(errs, fmt.Sprintf("[%d] port speed %d is not available at %s", i, o.PortSpeed, l.Name))
+	}
+	if o.DiversityZone != "" {
+		available := false
+		for _, z := range l.PortDiversityZones(o.PortSpeed) {
+			if z == o.DiversityZone {
+				available = true
+			}
+		}
+		if !available {
+			errs = append(errs, fmt.Sprintf("[%d] diversity zone %q is not available for %d Mbps ports at %s", i, o.DiversityZone, o.PortSpeed, l.Name))
+		}
+	}
+	return errs
+}
+
+func (s *Server) validateVxcOrder(prefix string, a *product, vo *vxcOrder) []string {
+	errs := []string{}
+	if vo.ProductName == "" {
+		errs = append(errs, prefix+" productName is required")
+	}
+	if vo.RateLimit == 0 || vo.RateLimit > a.speed {
+		errs = append(errs, fmt.Sprintf("%s rateLimit must be between 1 and %d", prefix, a.speed))
+	}
+	if vo.AEnd != nil && vo.AEnd.Vlan != 0 {
+		if vo.AEnd.Vlan < api.VlanMin || vo.AEnd.Vlan > api.VlanMax {
+			errs = append(errs, fmt.Sprintf("%s VLAN %d is out of range", prefix, vo.AEnd.Vlan))
+		} else if s.vlanInUse(a.uid, vo.AEnd.Vlan) {
+			errs = append(errs, fmt.Sprintf("%s VLAN %d is already in use on %s", prefix, vo.AEnd.Vlan, a.uid))
+		}
+	}
+	if vo.BEnd == nil || vo.BEnd.ProductUid == "" {
+		return append(errs, prefix+" bEnd.productUid is required")
+	}
+	if pp := s.partnerPort(vo.BEnd.ProductUid); pp != nil {
+		if !pp.VxcPermitted {
+			errs = append(errs, fmt.Sprintf("%s partner port %s does not accept VXCs", prefix, pp.ProductUid))
+		}
+		if vo.RateLimit > pp.Speed {
+			errs = append(errs, fmt.Sprintf("%s rateLimit exceeds the speed of partner port %s", prefix, pp.ProductUid))
+		}
+		if ls, ok := connectTypeRateLimits[pp.ConnectType]; ok {
+			permitted := false
+			for _, l := range ls {
+				if l == vo.RateLimit {
+					permitted = true
+				}
+			}
+			if !permitted {
+				errs = append(errs, fmt.Sprintf("%s rateLimit %d is not available for %s", prefix, vo.RateLimit, pp.ConnectType))
+			}
+		}
+		if pp.ConnectType == "" {
+			return errs
+		}
+		pc := vo.PartnerConfigs
+		if pc == nil || pc["connectType"] != pp.ConnectType {
+			return append(errs, fmt.Sprintf("%s partnerConfigs.connectType must be %s", prefix, pp.ConnectType))
+		}
+		if v, _ := pc["ownerAccount"].(string); v == "" {
+			errs = append(errs, prefix+" partnerConfigs.ownerAccount is required")
+		}
+		if v, _ := pc["asn"].(float64); v == 0 {
+			errs = append(errs, prefix+" partnerConfigs.asn is required")
+		}
+		if v, _ := pc["type"].(string); strings.ToLower(v) != "private" && strings.ToLower(v) != "public" {
+			errs = append(errs, prefix+" partnerConfigs.type must be either 'private' or 'public'")
+		}
+		return errs
+	}
+	b, ok := s.products[vo.BEnd.ProductUid]
+	if !ok || b.productType != api.ProductTypePort || b.status.IsTerminal() {
+		return append(errs, fmt.Sprintf("%s product %s cannot be used as a B-End", prefix, vo.BEnd.ProductUid))
+	}
+	if vo.RateLimit > b.speed {
+		errs = append(errs, fmt.Sprintf("%s rateLimit exceeds the speed of %s", prefix, b.uid))
+	}
+	if vo.BEnd.Vlan != 0 && s.vlanInUse(b.uid, vo.BEnd.Vlan) {
+		errs = append(errs, fmt.Sprintf("%s VLAN %d is already in use on %s", prefix, vo.BEnd.Vlan, b.uid))
+	}
+	return errs
+}
+
+func (s *Server) handleListProducts(w http.ResponseWriter, r *http.Request) {
+	companyUid, _ := s.requestCompany(r)
+	data := []interface{}{}
+	for _, uid := range s.productOrder {
+		p := s.products[uid]
+		if p.productType == api.ProductTypeVXC || p.companyUid != companyUid {
+			continue
+		}
+		data = append(data, s.productJSON(p))
+	}
+	writeData(w, "", data)
+}
+
+func (s *Server) handleGetProduct(w http.ResponseWriter, r *http.Request, uid string) {
+	p, ok := s.products[uid]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find product %s", uid), nil)
+		return
+	}
+	if next, ok := nextProvisioningStatus[p.status]; ok {
+		p.status = next
+	}
+	writeData(w, "", s.productJSON(p))
+}
+
+func (s *Server) handleUpdateProduct(w http.ResponseWriter, r *http.Request, productType, uid string) {
+	p, ok := s.products[uid]
+	if companyUid, _ := s.requestCompany(r); !ok || p.companyUid != companyUid {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find product %s", uid), nil)
+		return
+	}
+	if !strings.EqualFold(p.productType, productType) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Product %s is not of type %s", uid, productType), nil)
+		return
+	}
+	if p.status.IsTerminal() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Product %s has been cancelled", uid), nil)
+		return
+	}
+	u := &productUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(u); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse the update", err.Error())
+		return
+	}
+	if u.Name != nil {
+		p.name = *u.Name
+	}
+	if u.CostCentre != nil {
+		p.costCentre = *u.CostCentre
+	}
+	if u.MarketplaceVisibility != nil && p.productType == api.ProductTypePort {
+		p.marketplaceVisibility = *u.MarketplaceVisibility
+	}
+	if u.AEndVlan != nil && *u.AEndVlan != 0 && *u.AEndVlan != p.aEnd.vlan {
+		if s.vlanInUse(p.aEnd.productUid, *u.AEndVlan) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("VLAN %d is already in use", *u.AEndVlan), nil)
+			return
+		}
+		p.aEnd.vlan = *u.AEndVlan
+	}
+	if u.BEndVlan != nil && *u.BEndVlan != 0 && *u.BEndVlan != p.bEnd.vlan {
+		if s.vlanInUse(p.bEnd.productUid, *u.BEndVlan) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("VLAN %d is already in use", *u.BEndVlan), nil)
+			return
+		}
+		p.bEnd.vlan = *u.BEndVlan
+	}
+	if u.RateLimit != nil && *u.RateLimit != 0 && *u.RateLimit != p.rateLimit && p.productType == api.ProductTypeVXC {
+		if s.requiresSpeedApproval(p) {
+			p.speedChange = &speedChange{uid: uuid.New().String(), newSpeed: *u.RateLimit}
+			s.log(p, "SPEED_CHANGE", fmt.Sprintf("Speed change to %d Mbps requested", *u.RateLimit))
+		} else {
+			p.rateLimit = *u.RateLimit
+		}
+	}
+	s.log(p, "UPDATE", "Product updated")
+	writeData(w, "Product updated", s.productJSON(p))
+}
+
+func (s *Server) handleProductAction(w http.ResponseWriter, r *http.Request, uid, action string) {
+	p, ok := s.products[uid]
+	if companyUid, _ := s.requestCompany(r); !ok || p.companyUid != companyUid {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find product %s", uid), nil)
+		return
+	}
+	if action != "CANCEL_NOW" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported action %s", action), nil)
+		return
+	}
+	s.cancel(p)
+	for _, cuid := range s.productOrder {
+		c := s.products[cuid]
+		if c.productType == api.ProductTypeVXC && (c.aEnd.productUid == uid || c.bEnd.productUid == uid) {
+			s.cancel(c)
+		}
+	}
+	writeData(w, "Action CANCEL_NOW performed", nil)
+}
+
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request, uid string) {
+	if _, ok := s.products[uid]; uid != "" && !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Could not find product %s", uid), nil)
+		return
+	}
+	data := []interface{}{}
+	for i := len(s.logs) - 1; i >= 0; i-- {
+		l := s.logs[i]
+		if uid != "" && l.productUid != uid {
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"createDate":  l.createDate,
+			"description": l.message,
+			"interface":   "API",
+			"logType":     l.logType,
+			"message":     l.message,
+			"productName": l.productName,
+			"productType": l.productType,
+			"productUid":  l.productUid,
+			"userEmail":   s.UserName + "@example.com",
+			"userName":    s.UserName,
+		})
+	}
+	writeData(w, "", data)
+}
+
+func (s *Server) addProduct(p *product) {
+	s.products[p.uid] = p
+	s.productOrder = append(s.productOrder, p.uid)
+	s.log(p, "CREATE", "Product ordered")
+}
+
+func (s *Server) cancel(p *product) {
+	if !p.status.CanTransitionTo(api.ProvisioningStatusDecommissioning) || p.status == api.ProvisioningStatusDecommissioning {
+		return
+	}
+	p.status = api.ProvisioningStatusDecommissioning
+	s.log(p, "CANCEL", "Product cancelled")
+}
+
+func (s *Server) log(p *product, logType, message string) {
+	s.logs = append(s.logs, &activityLog{
+		createDate:  timestamp(),
+		logType:     logType,
+		message:     message,
+		productName: p.name,
+		productType: p.productType,
+		productUid:  p.uid,
+	})
+}
+
+func (s *Server) requiresSpeedApproval(p *product) bool {
+	if p.bEnd.partnerPortUid != "" {
+		return false
+	}
+	b, ok := s.products[p.bEnd.productUid]
+	return ok && b.companyUid != p.companyUid
+}
+
+func (s *Server) vlanInUse(portUid string, vlan uint64) bool {
+	for _, p := range s.products {
+		if p.productType != api.ProductTypeVXC || p.status.IsTerminal() {
+			continue
+		}
+		if (p.aEnd.productUid == portUid && p.aEnd.vlan == vlan) || (p.bEnd.productUid == portUid && p.bEnd.vlan == vlan) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) freeVlan(portUid string) uint64 {
+	for v := uint64(api.VlanMin); v <= api.VlanMax; v++ {
+		if !s.vlanInUse(portUid, v) {
+			return v
+		}
+	}
+	return 0
+}
+
+func (s *Server) location(id uint64) *api.Location {
+	for _, l := range s.locations {
+		if l.Id == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (s *Server) partnerPort(uid string) *api.Megaport {
+	for _, p := range s.partnerPorts {
+		if p.ProductUid == uid {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) productJSON(p *product) map[string]interface{} {
+	if p.productType == api.ProductTypeVXC {
+		return s.vxcJSON(p)
+	}
+	return s.portJSON(p)
+}
+
+func (s *Server) portJSON(p *product) map[string]interface{} {
+	vxcs := []interface{}{}
+	for _, uid := range s.productOrder {
+		v := s.products[uid]
+		if v.productType == api.ProductTypeVXC && !v.status.IsTerminal() && (v.aEnd.productUid == p.uid || v.bEnd.productUid == p.uid) {
+			vxcs = append(vxcs, s.vxcJSON(v))
+		}
+	}
+	market := ""
+	if l := s.location(p.locationId); l != nil {
+		market = l.Market
+	}
+	return map[string]interface{}{
+		"adminLocked":           false,
+		"aggregationId":         nil,
+		"associatedIxs":         []interface{}{},
+		"associatedVxcs":        vxcs,
+		"attributeTags":         map[string]interface{}{},
+		"buyoutPort":            false,
+		"cancelable":            !p.status.IsTerminal(),
+		"companyName":           p.companyName,
+		"companyUid":            p.companyUid,
+		"contractEndDate":       p.createDate + int64(p.term)*30*24*3600*1000,
+		"contractStartDate":     p.createDate,
+		"contractTermMonths":    p.term,
+		"costCentre":            p.costCentre,
+		"createDate":            p.createDate,
+		"createdBy":             s.UserName,
+		"diversityZone":         p.diversityZone,
+		"lagId":                 nil,
+		"lagPrimary":            false,
+		"liveDate":              p.createDate,
+		"locationId":            p.locationId,
+		"locked":                false,
+		"market":                market,
+		"marketplaceVisibility": p.marketplaceVisibility,
+		"portSpeed":             p.speed,
+		"productName":           p.name,
+		"productType":           p.productType,
+		"productUid":            p.uid,
+		"provisioningStatus":    p.status,
+		"resources": map[string]interface{}{
+			"interface": map[string]interface{}{
+				"demarcation":   "Rack 101, Panel 1, Port 1",
+				"description":   "",
+				"id":            float64(len(s.productOrder)),
+				"loa_template":  "megaport",
+				"media":         "LR",
+				"name":          "Interface",
+				"port_speed":    float64(p.speed),
+				"resource_name": "interface",
+				"resource_type": "interface",
+				"up":            float64(1),
+			},
+		},
+		"secondaryName":   nil,
+		"terminateDate":   nil,
+		"usageAlgorithm":  nil,
+		"virtual":         p.virtual,
+		"vxcAutoApproval": false,
+		"vxcPermitted":    true,
+	}
+}
+
+func (s *Server) vxcJSON(p *product) map[string]interface{} {
+	approval := map[string]interface{}{
+		"message":  nil,
+		"newSpeed": nil,
+		"status":   nil,
+		"type":     nil,
+		"uid":      nil,
+	}
+	if p.speedChange != nil {
+		approval = map[string]interface{}{
+			"message":  fmt.Sprintf("Speed change to %d Mbps is awaiting approval", p.speedChange.newSpeed),
+			"newSpeed": float64(p.speedChange.newSpeed),
+			"status":   api.VxcApprovalStatusPending,
+			"type":     api.VxcApprovalTypeSpeed,
+			"uid":      p.speedChange.uid,
+		}
+	}
+	resources := map[string]interface{}{
+		"vll": map[string]interface{}{
+			"a_vlan":          float64(p.aEnd.vlan),
+			"b_vlan":          float64(p.bEnd.vlan),
+			"description":     "",
+			"id":              float64(len(s.productOrder)),
+			"name":            p.name,
+			"rate_limit_mbps": float64(p.rateLimit),
+			"resource_name":   "vll",
+			"resource_type":   "vll",
+			"up":              float64(1),
+		},
+	}
+	if p.cspConnection != nil {
+		resources["csp_connection"] = p.cspConnection
+	}
+	return map[string]interface{}{
+		"adminLocked":        false,
+		"aEnd":               s.vxcEndJSON(p.aEnd),
+		"attributeTags":      map[string]interface{}{},
+		"bEnd":               s.vxcEndJSON(p.bEnd),
+		"cancelable":         !p.status.IsTerminal(),
+		"contractEndDate":    nil,
+		"contractStartDate":  nil,
+		"contractTermMonths": 1,
+		"costCentre":         p.costCentre,
+		"createDate":         p.createDate,
+		"createdBy":          s.UserName,
+		"distanceBand":       "ZONE",
+		"locked":             false,
+		"productName":        p.name,
+		"productType":        p.productType,
+		"productUid":         p.uid,
+		"provisioningStatus": p.status,
+		"rateLimit":          p.rateLimit,
+		"resources":          resources,
+		"secondaryName":      nil,
+		"usageAlgorithm":     "POST_PAID_HOURLY_SPEED_LONG_HAUL_VXC",
+		"vxcApproval":        approval,
+	}
+}
+
+func (s *Server) vxcEndJSON(e vxcEnd) map[string]interface{} {
+	ret := map[string]interface{}{
+		"productUid": e.productUid,
+		"vlan":       e.vlan,
+	}
+	var locationId uint64
+	if pp := s.partnerPort(e.partnerPortUid); pp != nil {
+		locationId = pp.LocationId
+		ret["ownerUid"] = pp.CompanyUid
+		ret["productName"] = pp.Title
+	} else if p, ok := s.products[e.productUid]; ok {
+		locationId = p.locationId
+		ret["ownerUid"] = p.companyUid
+		ret["productName"] = p.name
+	}
+	ret["locationId"] = locationId
+	if l := s.location(locationId); l != nil {
+		ret["location"] = l.Name
+	}
+	return ret
+}
+
+func cspConnectionJSON(pp *api.Megaport, pc map[string]interface{}, vlan uint64) map[string]interface{} {
+	str := func(k, def string) string {
+		if v, ok := pc[k].(string); ok && v != "" {
+			return v
+		}
+		return def
+	}
+	asn, _ := pc["asn"].(float64)
+	return map[string]interface{}{
+		"account":           pp.CompanyUid,
+		"amazonAsn":         float64(64512),
+		"amazonIpAddress":   str("amazonIpAddress", "169.254.0.1/30"),
+		"Amazon_address":    str("amazonIpAddress", "169.254.0.1/30"),
+		"asn":               asn,
+		"authKey":           str("authKey", strings.Replace(uuid.New().String(), "-", "", -1)),
+		"connectType":       pp.ConnectType,
+		"customerIpAddress": str("customerIpAddress", "169.254.0.2/30"),
+		"id":                float64(vlan),
+		"name":              str("name", ""),
+		"ownerAccount":      str("ownerAccount", ""),
+		"peerAsn":           float64(64512),
+		"Resource_name":     "b_csp_connection",
+		"Resource_type":     "csp_connection",
+		"type":              strings.ToLower(str("type", "private")),
+		"Vif_id":            "dxvif-" + strings.Replace(uuid.New().String(), "-", "", -1)[:8],
+		"vlan":              float64(vlan),
+	}
+}
+
+func timestamp() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
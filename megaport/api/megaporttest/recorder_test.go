@@ -0,0 +1,97 @@
+package megaporttest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "megaporttest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixture.json")
+
+	s := NewServer()
+	rec, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	c := api.NewClient(s.URL)
+	c.SetTransport(rec)
+	if err := c.Login("alice@example.com", "s3cr3t", ""); err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	name := rec.Value("name", "foo")
+	uid, err := c.CreatePort(&api.PortCreateInput{
+		LocationId: api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:       &name,
+		Speed:      api.Uint64(uint64(1000)),
+		Term:       api.Uint64(uint64(1)),
+	})
+	if err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	recorded := []api.ProvisioningStatus{}
+	for i := 0; i < 2; i++ {
+		p, err := c.GetPort(*uid)
+		if err != nil {
+			t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+		}
+		recorded = append(recorded, p.ProvisioningStatus)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	s.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{s.Token, "s3cr3t", "alice"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("TestRecorder_recordAndReplay: fixture contains unscrubbed value %q", secret)
+		}
+	}
+
+	rep, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	c = api.NewClient(api.EndpointStaging)
+	c.SetTransport(rep)
+	if v := rep.Value("name", "bar"); v != name {
+		t.Errorf("TestRecorder_recordAndReplay: unexpected replayed value: got %q, expected %q", v, name)
+	}
+	ruid, err := c.CreatePort(&api.PortCreateInput{
+		LocationId: api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:       &name,
+		Speed:      api.Uint64(uint64(1000)),
+		Term:       api.Uint64(uint64(1)),
+	})
+	if err != nil {
+		t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+	}
+	if *ruid != *uid {
+		t.Errorf("TestRecorder_recordAndReplay: unexpected replayed uid: got %s, expected %s", *ruid, *uid)
+	}
+	for i, e := range append(recorded, recorded[len(recorded)-1]) {
+		p, err := c.GetPort(*uid)
+		if err != nil {
+			t.Fatalf("TestRecorder_recordAndReplay: %v", err)
+		}
+		if p.ProvisioningStatus != e {
+			t.Errorf("TestRecorder_recordAndReplay: unexpected replayed status #%d: got %s, expected %s", i, p.ProvisioningStatus, e)
+		}
+	}
+	if _, err := c.GetLocations(); err == nil {
+		t.Errorf("TestRecorder_recordAndReplay: expected an error for an interaction that was not recorded")
+	}
+}
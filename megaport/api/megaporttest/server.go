@@ -0,0 +1,242 @@
+// Package megaporttest provides an in-memory, stateful implementation of the
+// subset of the Megaport API used by this provider, so that the api client and
+// the provider can be exercised without network access.
+package megaporttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+const (
+	DefaultCompanyName = "Megaport Test Company"
+	DefaultUserName    = "megaporttest"
+)
+
+// Server is a fake Megaport API listening on a local address. Products that
+// are bought through it advance one provisioning status every time they are
+// read, so that waiting for a product to become live terminates quickly.
+type Server struct {
+	URL         string
+	Token       string
+	CompanyUid  string
+	CompanyName string
+	UserName    string
+
+	srv          *httptest.Server
+	mu           sync.Mutex
+	locations    []*api.Location
+	partnerPorts []*api.Megaport
+	ixs          map[uint64][]*api.InternetExchange
+	products     map[string]*product
+	productOrder []string
+	logs         []*activityLog
+
+	managedCompanies []*api.ManagedCompany
+}
+
+// NewServer starts a fake API seeded with a few locations and partner ports.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Token:        uuid.New().String(),
+		CompanyUid:   uuid.New().String(),
+		CompanyName:  DefaultCompanyName,
+		UserName:     DefaultUserName,
+		locations:    defaultLocations(),
+		partnerPorts: defaultPartnerPorts(),
+		ixs:          defaultInternetExchanges(),
+		products:     map[string]*product{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an api client that is authenticated against the server.
+func (s *Server) Client() *api.Client {
+	c := api.NewClient(s.URL)
+	c.Token = s.Token
+	return c
+}
+
+// AddLocation makes an additional location available.
+func (s *Server) AddLocation(l *api.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locations = append(s.locations, l)
+}
+
+// AddManagedCompany adds a company managed by the server's company, on
+// behalf of which products can be ordered, and returns its uid.
+func (s *Server) AddManagedCompany(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mc := &api.ManagedCompany{
+		AccountName: name,
+		AccountRef:  fmt.Sprintf("REF-%d", len(s.managedCompanies)+1),
+		CompanyUid:  uuid.New().String(),
+	}
+	s.managedCompanies = append(s.managedCompanies, mc)
+	return mc.CompanyUid
+}
+
+// AddPartnerPort makes an additional partner port available.
+func (s *Server) AddPartnerPort(p *api.Megaport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partnerPorts = append(s.partnerPorts, p)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(p) < 2 || p[0] != "v2" {
+		writeError(w, http.StatusNotFound, "Not found", nil)
+		return
+	}
+	if p[1] == "login" && r.Method == http.MethodPost {
+		s.handleLogin(w, r)
+		return
+	}
+	if r.Header.Get("X-Auth-Token") != s.Token {
+		writeError(w, http.StatusUnauthorized, "Invalid or missing token", nil)
+		return
+	}
+	if uid, _ := s.requestCompany(r); uid == "" {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Company %s is not managed by %s", r.Header.Get("X-Company-Uid"), s.CompanyName), nil)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && len(p) == 2 && p[1] == "logout":
+		writeData(w, "Logged out", nil)
+	case r.Method == http.MethodGet && len(p) == 2 && p[1] == "locations":
+		s.handleLocations(w, r)
+	case r.Method == http.MethodGet && len(p) == 2 && p[1] == "managedCompanies":
+		s.handleManagedCompanies(w, r)
+	case r.Method == http.MethodGet && len(p) == 4 && p[1] == "dropdowns" && p[2] == "partner" && p[3] == "megaports":
+		s.handlePartnerPorts(w, r)
+	case r.Method == http.MethodGet && len(p) == 4 && p[1] == "product" && p[2] == "ix" && p[3] == "types":
+		s.handleInternetExchanges(w, r)
+	case r.Method == http.MethodGet && len(p) == 3 && p[1] == "pricebook":
+		s.handlePricebook(w, r, p[2])
+	case r.Method == http.MethodPost && len(p) == 3 && p[1] == "networkdesign" && p[2] == "validate":
+		s.handleValidate(w, r)
+	case r.Method == http.MethodPost && len(p) == 3 && p[1] == "networkdesign" && p[2] == "buy":
+		s.handleBuy(w, r)
+	case r.Method == http.MethodGet && len(p) == 2 && p[1] == "products":
+		s.handleListProducts(w, r)
+	case r.Method == http.MethodGet && len(p) == 3 && p[1] == "company" && p[2] == "logs":
+		s.handleLogs(w, r, "")
+	case r.Method == http.MethodGet && len(p) == 3 && p[1] == "product":
+		s.handleGetProduct(w, r, p[2])
+	case r.Method == http.MethodGet && len(p) == 4 && p[1] == "product" && p[3] == "logs":
+		s.handleLogs(w, r, p[2])
+	case r.Method == http.MethodPut && len(p) == 4 && p[1] == "product":
+		s.handleUpdateProduct(w, r, p[2], p[3])
+	case r.Method == http.MethodPost && len(p) == 5 && p[1] == "product" && p[3] == "action":
+		s.handleProductAction(w, r, p[2], p[4])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cannot %s %s", r.Method, r.URL.Path), nil)
+	}
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	if r.Form.Get("username") == "" || r.Form.Get("password") == "" {
+		writeError(w, http.StatusUnauthorized, "Invalid username or password", nil)
+		return
+	}
+	writeData(w, "Login successful", map[string]interface{}{"token": s.Token})
+}
+
+func (s *Server) handleLocations(w http.ResponseWriter, r *http.Request) {
+	data := make([]interface{}, len(s.locations))
+	for i, l := range s.locations {
+		data[i] = locationJSON(l)
+	}
+	writeData(w, "", data)
+}
+
+func (s *Server) handlePartnerPorts(w http.ResponseWriter, r *http.Request) {
+	data := make([]interface{}, len(s.partnerPorts))
+	for i, p := range s.partnerPorts {
+		data[i] = partnerPortJSON(p)
+	}
+	writeData(w, "", data)
+}
+
+func (s *Server) handleInternetExchanges(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("locationId"), 10, 64)
+	if err != nil || s.location(id) == nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid locationId: %q", r.URL.Query().Get("locationId")), nil)
+		return
+	}
+	data := []interface{}{}
+	for _, ix := range s.ixs[id] {
+		data = append(data, internetExchangeJSON(ix))
+	}
+	writeData(w, "", data)
+}
+
+func (s *Server) handleManagedCompanies(w http.ResponseWriter, r *http.Request) {
+	data := make([]interface{}, len(s.managedCompanies))
+	for i, mc := range s.managedCompanies {
+		data[i] = map[string]interface{}{
+			"accountName": mc.AccountName,
+			"accountRef":  mc.AccountRef,
+			"companyUid":  mc.CompanyUid,
+		}
+	}
+	writeData(w, "", data)
+}
+
+// requestCompany returns the company a request acts on behalf of, or an empty
+// uid if it is scoped to a company that the server's company does not manage.
+func (s *Server) requestCompany(r *http.Request) (uid, name string) {
+	h := r.Header.Get("X-Company-Uid")
+	if h == "" || h == s.CompanyUid {
+		return s.CompanyUid, s.CompanyName
+	}
+	for _, mc := range s.managedCompanies {
+		if mc.CompanyUid == h {
+			return mc.CompanyUid, mc.AccountName
+		}
+	}
+	return "", ""
+}
+
+func writeData(w http.ResponseWriter, message string, data interface{}) {
+	writeResponse(w, http.StatusOK, message, data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string, data interface{}) {
+	writeResponse(w, status, message, data)
+}
+
+func writeResponse(w http.ResponseWriter, status int, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"terms":   "This data is subject to the Acceptable Use Policy https://www.megaport.com/legal/acceptable-use-policy",
+		"data":    data,
+	})
+}
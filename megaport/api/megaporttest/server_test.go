@@ -0,0 +1,255 @@
+package megaporttest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func TestServer_portLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client()
+	if _, err := c.CreatePort(&api.PortCreateInput{
+		LocationId: api.Uint64(uint64(LocationEquinixSY1)),
+		Name:       api.String("foo"),
+		Speed:      api.Uint64(uint64(100000)),
+		Term:       api.Uint64(uint64(1)),
+	}); err == nil {
+		t.Errorf("TestServer_portLifecycle: expected an error when ordering an unavailable speed")
+	}
+	uid, err := c.CreatePort(&api.PortCreateInput{
+		LocationId:       api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:             api.String("foo"),
+		Speed:            api.Uint64(uint64(10000)),
+		Term:             api.Uint64(uint64(12)),
+		InvoiceReference: api.String("bar"),
+	})
+	if err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	for _, e := range []api.ProvisioningStatus{api.ProvisioningStatusConfigured, api.ProvisioningStatusLive, api.ProvisioningStatusLive} {
+		p, err := c.GetPort(*uid)
+		if err != nil {
+			t.Fatalf("TestServer_portLifecycle: %v", err)
+		}
+		if p.ProvisioningStatus != e {
+			t.Errorf("TestServer_portLifecycle: unexpected status: got %s, expected %s", p.ProvisioningStatus, e)
+		}
+	}
+	if err := c.UpdatePort(&api.PortUpdateInput{ProductUid: uid, Name: api.String("baz")}); err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	ports, err := c.ListPorts()
+	if err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	if len(ports) != 1 || ports[0].ProductName != "baz" || ports[0].CostCentre != "bar" || ports[0].PortSpeed != 10000 {
+		t.Errorf("TestServer_portLifecycle: unexpected port list: %#v", ports)
+	}
+	if err := c.DeletePort(*uid); err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	p, err := c.GetPort(*uid)
+	if err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	if p.ProvisioningStatus != api.ProvisioningStatusDecommissioned {
+		t.Errorf("TestServer_portLifecycle: unexpected status: got %s, expected %s", p.ProvisioningStatus, api.ProvisioningStatusDecommissioned)
+	}
+	logs, err := c.GetProductActivity(*uid)
+	if err != nil {
+		t.Fatalf("TestServer_portLifecycle: %v", err)
+	}
+	if len(logs) != 3 || logs[0].LogType != "CANCEL" {
+		t.Errorf("TestServer_portLifecycle: unexpected activity log: %#v", logs)
+	}
+}
+
+func TestServer_vxcSpeedApproval(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client()
+	a, err := c.CreatePort(&api.PortCreateInput{
+		LocationId: api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:       api.String("a"),
+		Speed:      api.Uint64(uint64(1000)),
+		Term:       api.Uint64(uint64(1)),
+	})
+	if err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	b := s.AddPort(&api.Product{
+		CompanyName: "Other Company",
+		CompanyUid:  "other",
+		LocationId:  LocationEquinixLD5,
+		PortSpeed:   1000,
+		ProductName: "b",
+	})
+	uid, err := c.CreatePrivateVxc(&api.PrivateVxcCreateInput{
+		Name:        api.String("vxc"),
+		ProductUidA: a,
+		ProductUidB: &b,
+		RateLimit:   api.Uint64(uint64(100)),
+	})
+	if err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	pending, err := c.UpdatePrivateVxc(&api.PrivateVxcUpdateInput{ProductUid: uid, RateLimit: api.Uint64(uint64(200))})
+	if err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	if pending == nil || pending.NewSpeed != 200 {
+		t.Fatalf("TestServer_vxcSpeedApproval: expected a pending speed change to 200, got %#v", pending)
+	}
+	v, err := c.GetPrivateVxc(*uid)
+	if err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	if v.RateLimit != 100 || v.AEnd.Vlan == 0 || v.BEnd.OwnerUid != "other" {
+		t.Errorf("TestServer_vxcSpeedApproval: unexpected vxc: %#v", v)
+	}
+	if err := s.ApproveSpeedChange(*uid); err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	v, err = c.GetPrivateVxc(*uid)
+	if err != nil {
+		t.Fatalf("TestServer_vxcSpeedApproval: %v", err)
+	}
+	if r, ok := v.PendingRateLimit(); v.RateLimit != 200 || ok {
+		t.Errorf("TestServer_vxcSpeedApproval: unexpected rate limit after approval: got %d (pending %d)", v.RateLimit, r)
+	}
+}
+
+func TestServer_provisioningStatusTransitions(t *testing.T) {
+	for from, to := range nextProvisioningStatus {
+		if !from.CanTransitionTo(to) {
+			t.Errorf("TestServer_provisioningStatusTransitions: %s cannot transition to %s", from, to)
+		}
+	}
+}
+
+func TestServer_queryLocations(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	ll, err := s.Client().QueryLocations(&api.LocationQuery{
+		Status:    api.String(api.LocationStatusActive),
+		PortSpeed: api.Uint64(uint64(100000)),
+		Latitude:  api.Float64(51.51),
+		Longitude: api.Float64(-0.59),
+	})
+	if err != nil {
+		t.Fatalf("TestServer_queryLocations: %v", err)
+	}
+	if len(ll) == 0 || ll[0].Id != LocationTelehouseNorth {
+		t.Errorf("TestServer_queryLocations: unexpected nearest location: %v", ll)
+	}
+}
+
+func TestServer_internetExchanges(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	ixs, err := s.Client().GetInternetExchanges(LocationEquinixFR5)
+	if err != nil {
+		t.Fatalf("TestServer_internetExchanges: %v", err)
+	}
+	if len(ixs) != 1 || ixs[0].ASN != 6695 || ixs[0].PrimaryIPv4.Value == "" || ixs[0].SecondaryIPv4.Value != "" {
+		t.Errorf("TestServer_internetExchanges: unexpected exchanges: %v", ixs)
+	}
+	if _, err := s.Client().GetInternetExchanges(0); err == nil {
+		t.Errorf("TestServer_internetExchanges: expected an error for an unknown location")
+	}
+}
+
+func TestServer_diversityZones(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client()
+	if _, err := c.CreatePort(&api.PortCreateInput{
+		DiversityZone: api.String(api.DiversityZoneBlue),
+		LocationId:    api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:          api.String("foo"),
+		Speed:         api.Uint64(uint64(100000)),
+		Term:          api.Uint64(uint64(1)),
+	}); err == nil {
+		t.Errorf("TestServer_diversityZones: expected an error when ordering a port in an unavailable zone")
+	}
+	for _, tc := range []struct {
+		zone     *string
+		expected string
+	}{
+		{nil, api.DiversityZoneRed},
+		{api.String(api.DiversityZoneBlue), api.DiversityZoneBlue},
+	} {
+		uid, err := c.CreatePort(&api.PortCreateInput{
+			DiversityZone: tc.zone,
+			LocationId:    api.Uint64(uint64(LocationTelehouseNorth)),
+			Name:          api.String("foo"),
+			Speed:         api.Uint64(uint64(10000)),
+			Term:          api.Uint64(uint64(1)),
+		})
+		if err != nil {
+			t.Fatalf("TestServer_diversityZones: %v", err)
+		}
+		p, err := c.GetPort(*uid)
+		if err != nil {
+			t.Fatalf("TestServer_diversityZones: %v", err)
+		}
+		if p.DiversityZone != tc.expected {
+			t.Errorf("TestServer_diversityZones: unexpected zone: got %q, expected %q", p.DiversityZone, tc.expected)
+		}
+	}
+}
+
+func TestServer_managedCompanies(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := s.Client()
+	companyUid := s.AddManagedCompany("foo")
+	mc, err := c.ListManagedCompanies()
+	if err != nil {
+		t.Fatalf("TestServer_managedCompanies: %v", err)
+	}
+	if len(mc) != 1 || mc[0].CompanyUid != companyUid || mc[0].AccountName != "foo" {
+		t.Errorf("TestServer_managedCompanies: unexpected managed companies: %#v", mc)
+	}
+	if _, err := c.ForCompany(uuid.New().String()).ListPorts(); err == nil {
+		t.Errorf("TestServer_managedCompanies: expected an error when acting on behalf of an unmanaged company")
+	}
+	mcc := c.ForCompany(companyUid)
+	uid, err := mcc.CreatePort(&api.PortCreateInput{
+		LocationId: api.Uint64(uint64(LocationTelehouseNorth)),
+		Name:       api.String("foo"),
+		Speed:      api.Uint64(uint64(1000)),
+		Term:       api.Uint64(uint64(1)),
+	})
+	if err != nil {
+		t.Fatalf("TestServer_managedCompanies: %v", err)
+	}
+	p, err := c.GetPort(*uid)
+	if err != nil {
+		t.Fatalf("TestServer_managedCompanies: %v", err)
+	}
+	if p.CompanyUid != companyUid || p.CompanyName != "foo" {
+		t.Errorf("TestServer_managedCompanies: unexpected owner: %s (%s)", p.CompanyUid, p.CompanyName)
+	}
+	for _, tc := range []struct {
+		c        *api.Client
+		expected int
+	}{{c, 0}, {mcc, 1}} {
+		ports, err := tc.c.ListPorts()
+		if err != nil {
+			t.Fatalf("TestServer_managedCompanies: %v", err)
+		}
+		if len(ports) != tc.expected {
+			t.Errorf("TestServer_managedCompanies: unexpected number of ports for %q: got %d, expected %d", tc.c.CompanyUid, len(ports), tc.expected)
+		}
+	}
+	if err := c.DeletePort(*uid); err == nil {
+		t.Errorf("TestServer_managedCompanies: expected an error when deleting a port of a managed company without acting on its behalf")
+	}
+	if err := mcc.DeletePort(*uid); err != nil {
+		t.Errorf("TestServer_managedCompanies: %v", err)
+	}
+}
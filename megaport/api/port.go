@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -11,6 +12,11 @@ const (
 	ProductTypeMCR1 = "MEGAPORT"
 	ProductTypeMCR2 = "MCR2"
 	ProductTypeVXC  = "VXC"
+	ProductTypeIX   = "IX"
+
+	ProductKindPort = "port"
+	ProductKindMCR1 = "mcr1"
+	ProductKindMCR2 = "mcr2"
 )
 
 // port: virtual = false, type = MEGAPORT
@@ -20,11 +26,12 @@ const (
 type portCreatePayload struct {
 	CreateDate            *uint64 `json:"createDate,omitempty"` // TODO: need to fill in? :o
 	CostCentre            *string `json:"costCentre"`
+	DiversityZone         *string `json:"diversityZone,omitempty"`
 	LagPortCount          *uint64 `json:"lagPortCount,omitempty"` // TODO: Required: the number of ports in this LAG order (https://dev.megaport.com/#standard-api-orders-validate-lag-order)
 	LocationId            *uint64 `json:"locationId"`
 	LocationUid           *string `json:"locationUid,omitempty"` // TODO: null in example, is it a string? https://dev.megaport.com/#standard-api-orders-validate-port-order
 	Market                *string `json:"market,omitempty"`      // TODO: what is this ???
-	PortSpeed             *uint64 `json:"portSpeed"`             // TODO: validate 1000, 10000, 100000
+	PortSpeed             *uint64 `json:"portSpeed"`
 	ProductName           *string `json:"productName"`
 	ProductType           *string `json:"productType"` // TODO: "MEGAPORT"?
 	Term                  *uint64 `json:"term"`
@@ -40,6 +47,7 @@ type portUpdatePayload struct {
 }
 
 type PortCreateInput struct {
+	DiversityZone         *string
 	LocationId            *uint64
 	MarketplaceVisibility *bool
 	Name                  *string
@@ -56,6 +64,7 @@ func (v *PortCreateInput) toPayload() ([]byte, error) {
 	payload := []*portCreatePayload{{
 		LocationId:            v.LocationId,
 		CostCentre:            v.InvoiceReference,
+		DiversityZone:         v.DiversityZone,
 		PortSpeed:             v.Speed,
 		ProductName:           v.Name,
 		ProductType:           String(ProductTypePort), // TODO
@@ -86,6 +95,20 @@ func (v *PortUpdateInput) toPayload() ([]byte, error) {
 	return json.Marshal(payload)
 }
 
+// Kind tells ports and MCRs apart, since ports and MCR1s share a product type.
+// Other products are identified by their lowercased product type.
+func (p *Product) Kind() string {
+	switch {
+	case p.ProductType == ProductTypeMCR2:
+		return ProductKindMCR2
+	case p.ProductType == ProductTypePort && p.Virtual:
+		return ProductKindMCR1
+	case p.ProductType == ProductTypePort:
+		return ProductKindPort
+	}
+	return strings.ToLower(p.ProductType)
+}
+
 func (c *Client) CreatePort(v *PortCreateInput) (*string, error) {
 	d, err := c.create(v)
 	if err != nil {
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestProduct_Kind(t *testing.T) {
+	for i, tc := range []struct {
+		p        *Product
+		expected string
+	}{
+		{&Product{ProductType: ProductTypePort}, ProductKindPort},
+		{&Product{ProductType: ProductTypeMCR1, Virtual: true}, ProductKindMCR1},
+		{&Product{ProductType: ProductTypeMCR2}, ProductKindMCR2},
+		{&Product{ProductType: ProductTypeIX}, "ix"},
+	} {
+		if k := tc.p.Kind(); k != tc.expected {
+			t.Errorf("Product.Kind (#%d): expected %q, got %q", i, tc.expected, k)
+		}
+	}
+}
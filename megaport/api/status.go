@@ -0,0 +1,120 @@
+package api
+
+// ProvisioningStatus is the lifecycle state of a product.
+type ProvisioningStatus string
+
+const (
+	ProvisioningStatusNew             ProvisioningStatus = "NEW"
+	ProvisioningStatusDesign          ProvisioningStatus = "DESIGN"
+	ProvisioningStatusDeployable      ProvisioningStatus = "DEPLOYABLE"
+	ProvisioningStatusConfigured      ProvisioningStatus = "CONFIGURED"
+	ProvisioningStatusLive            ProvisioningStatus = "LIVE"
+	ProvisioningStatusDecommissioning ProvisioningStatus = "DECOMMISSIONING"
+	ProvisioningStatusDecommissioned  ProvisioningStatus = "DECOMMISSIONED"
+	ProvisioningStatusCancelled       ProvisioningStatus = "CANCELLED"
+	ProvisioningStatusCancelledParent ProvisioningStatus = "CANCELLED_PARENT"
+)
+
+// provisioningStatusTransitions lists the statuses a product can move to from
+// each status:
+//
+//	NEW             -> DESIGN, DEPLOYABLE, CANCELLED
+//	DESIGN          -> DEPLOYABLE, CANCELLED
+//	DEPLOYABLE      -> CONFIGURED, LIVE, DECOMMISSIONING, CANCELLED, CANCELLED_PARENT
+//	CONFIGURED      -> LIVE, DECOMMISSIONING, DECOMMISSIONED, CANCELLED_PARENT
+//	LIVE            -> DECOMMISSIONING, DECOMMISSIONED, CANCELLED_PARENT
+//	DECOMMISSIONING -> DECOMMISSIONED
+//
+// New products start as NEW or DESIGN when they are validated, and become
+// DEPLOYABLE once they are ordered. They are CONFIGURED when provisioned on
+// the Megaport side and LIVE once traffic is seen (for ports, once the cross
+// connect is in place). CANCELLED_PARENT is used for VXCs that are removed
+// because one of their ends was. The terminal statuses have no transitions.
+var provisioningStatusTransitions = map[ProvisioningStatus][]ProvisioningStatus{
+	ProvisioningStatusNew: {
+		ProvisioningStatusDesign,
+		ProvisioningStatusDeployable,
+		ProvisioningStatusCancelled,
+	},
+	ProvisioningStatusDesign: {
+		ProvisioningStatusDeployable,
+		ProvisioningStatusCancelled,
+	},
+	ProvisioningStatusDeployable: {
+		ProvisioningStatusConfigured,
+		ProvisioningStatusLive,
+		ProvisioningStatusDecommissioning,
+		ProvisioningStatusCancelled,
+		ProvisioningStatusCancelledParent,
+	},
+	ProvisioningStatusConfigured: {
+		ProvisioningStatusLive,
+		ProvisioningStatusDecommissioning,
+		ProvisioningStatusDecommissioned,
+		ProvisioningStatusCancelledParent,
+	},
+	ProvisioningStatusLive: {
+		ProvisioningStatusDecommissioning,
+		ProvisioningStatusDecommissioned,
+		ProvisioningStatusCancelledParent,
+	},
+	ProvisioningStatusDecommissioning: {
+		ProvisioningStatusDecommissioned,
+	},
+}
+
+// IsTerminal reports whether the product has been removed and will not change
+// status again.
+func (s ProvisioningStatus) IsTerminal() bool {
+	switch s {
+	case ProvisioningStatusDecommissioned, ProvisioningStatusCancelled, ProvisioningStatusCancelledParent:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsActive reports whether the product has been provisioned and is in service.
+func (s ProvisioningStatus) IsActive() bool {
+	switch s {
+	case ProvisioningStatusConfigured, ProvisioningStatusLive:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPending reports whether the product is on its way to being provisioned or
+// removed, and its status is expected to change without further action.
+func (s ProvisioningStatus) IsPending() bool {
+	switch s {
+	case ProvisioningStatusNew, ProvisioningStatusDesign, ProvisioningStatusDeployable, ProvisioningStatusDecommissioning:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsKnown reports whether the status is one of the documented statuses.
+func (s ProvisioningStatus) IsKnown() bool {
+	return s.IsTerminal() || s.IsActive() || s.IsPending()
+}
+
+// CanTransitionTo reports whether a product can move from status s to status
+// t. Staying in the same status is always allowed.
+func (s ProvisioningStatus) CanTransitionTo(t ProvisioningStatus) bool {
+	if s == t {
+		return true
+	}
+	for _, n := range provisioningStatusTransitions[s] {
+		if n == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Transitions returns the statuses a product can move to from status s.
+func (s ProvisioningStatus) Transitions() []ProvisioningStatus {
+	return append([]ProvisioningStatus(nil), provisioningStatusTransitions[s]...)
+}
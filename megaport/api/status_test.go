@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestProvisioningStatus(t *testing.T) {
+	testCases := []struct {
+		s        ProvisioningStatus
+		terminal bool
+		active   bool
+		pending  bool
+	}{
+		{s: ProvisioningStatusNew, pending: true},              // 0
+		{s: ProvisioningStatusDesign, pending: true},           // 1
+		{s: ProvisioningStatusDeployable, pending: true},       // 2
+		{s: ProvisioningStatusConfigured, active: true},        // 3
+		{s: ProvisioningStatusLive, active: true},              // 4
+		{s: ProvisioningStatusDecommissioning, pending: true},  // 5
+		{s: ProvisioningStatusDecommissioned, terminal: true},  // 6
+		{s: ProvisioningStatusCancelled, terminal: true},       // 7
+		{s: ProvisioningStatusCancelledParent, terminal: true}, // 8
+		{s: ProvisioningStatus("SOMETHING_NEW")},               // 9
+	}
+	for i, tc := range testCases {
+		if tc.s.IsTerminal() != tc.terminal || tc.s.IsActive() != tc.active || tc.s.IsPending() != tc.pending {
+			t.Errorf("ProvisioningStatus (#%d): unexpected predicates for %s: terminal=%v active=%v pending=%v", i, tc.s, tc.s.IsTerminal(), tc.s.IsActive(), tc.s.IsPending())
+		}
+		if tc.s.IsKnown() != (i < 9) {
+			t.Errorf("ProvisioningStatus (#%d): unexpected IsKnown for %s", i, tc.s)
+		}
+		if tc.s.IsTerminal() && len(tc.s.Transitions()) != 0 {
+			t.Errorf("ProvisioningStatus (#%d): terminal status %s has transitions %v", i, tc.s, tc.s.Transitions())
+		}
+		if tc.s.IsKnown() && !tc.s.IsTerminal() && len(tc.s.Transitions()) == 0 {
+			t.Errorf("ProvisioningStatus (#%d): status %s has no transitions", i, tc.s)
+		}
+	}
+}
+
+func TestProvisioningStatus_CanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from ProvisioningStatus
+		to   ProvisioningStatus
+		ok   bool
+	}{
+		{ProvisioningStatusDeployable, ProvisioningStatusConfigured, true},          // 0
+		{ProvisioningStatusConfigured, ProvisioningStatusLive, true},                // 1
+		{ProvisioningStatusLive, ProvisioningStatusDecommissioning, true},           // 2
+		{ProvisioningStatusDecommissioning, ProvisioningStatusDecommissioned, true}, // 3
+		{ProvisioningStatusLive, ProvisioningStatusLive, true},                      // 4
+		{ProvisioningStatusLive, ProvisioningStatusConfigured, false},               // 5
+		{ProvisioningStatusDecommissioned, ProvisioningStatusLive, false},           // 6
+		{ProvisioningStatusDecommissioning, ProvisioningStatusLive, false},          // 7
+		{ProvisioningStatusNew, ProvisioningStatusLive, false},                      // 8
+	}
+	for i, tc := range testCases {
+		if ok := tc.from.CanTransitionTo(tc.to); ok != tc.ok {
+			t.Errorf("ProvisioningStatus.CanTransitionTo (#%d): %s -> %s: got %v, expected %v", i, tc.from, tc.to, ok, tc.ok)
+		}
+	}
+}
@@ -14,6 +14,11 @@ type megaportResponse struct {
 	Data    interface{}
 }
 
+type megaportRawResponse struct {
+	Message string
+	Data    json.RawMessage
+}
+
 type responseLoginData struct {
 	Token string
 }
@@ -23,6 +28,7 @@ type Location struct {
 	Address          LocationAddress
 	Campus           string
 	Country          string
+	DiversityZones   LocationDiversityZones
 	Id               uint64
 	Latitude         float64
 	LiveDate         uint64
@@ -35,6 +41,7 @@ type Location struct {
 	SiteCode         string
 	Status           string
 	VRouterAvailable bool
+	Raw              RawFields `json:"-"`
 }
 
 // Address data
@@ -47,6 +54,23 @@ type LocationAddress struct {
 	Suburb   string
 }
 
+// LocationDiversityZones holds the diversity zones of a location, keyed by
+// zone, with the speeds (in Mbps) at which products can be ordered in each of
+// them. Locations without zones omit the field. The shape follows the
+// diversityZones of the public megaportgo client. TODO: it has not been
+// checked against a recorded response.
+type LocationDiversityZones struct {
+	Red  *LocationDiversityZone
+	Blue *LocationDiversityZone
+}
+
+type LocationDiversityZone struct {
+	McrSpeedMbps       []uint64 `json:"mcr_speed_mbps"`
+	MegaportSpeedMbps  []uint64 `json:"megaport_speed_mbps"`
+	MveAvailable       bool     `json:"mve_available"`
+	MveMaxCpuCoreCount *uint64  `json:"mve_max_cpu_core_count"`
+}
+
 type LocationProducts struct {
 	MCR        bool
 	MCRVersion uint64
@@ -60,6 +84,7 @@ type Megaport struct {
 	CompanyName   string
 	CompanyUid    string
 	ConnectType   string
+	DiversityZone string
 	LagId         uint64 `json:"lag_id"`
 	LagPrimary    bool   `json:"lag_primary"`
 	LocationId    uint64
@@ -68,6 +93,7 @@ type Megaport struct {
 	Speed         uint64
 	Title         string
 	VxcPermitted  bool
+	Raw           RawFields `json:"-"`
 }
 
 type InternetExchange struct {
@@ -82,6 +108,7 @@ type InternetExchange struct {
 	SecondaryIPv4 InternetExchangeIPAddress
 	SecondaryIPv6 InternetExchangeIPAddress
 	State         string
+	Raw           RawFields `json:"-"`
 }
 
 type InternetExchangeIPAddress struct {
@@ -90,22 +117,23 @@ type InternetExchangeIPAddress struct {
 }
 
 type Product struct {
-	AdminLocked bool
-	// AggregationId // TODO: haven't seen a value other than null
-	// AssociatedIxs []ProductsAssociatedIx // TODO: haven't seen a value other than an empty list
-	AssociatedVxcs []ProductAssociatedVxc
-	// AttributeTags // TODO: haven't seen a value other than an empty map
-	BuyoutPort         bool
-	Cancelable         bool
-	CompanyName        string
-	CompanyUid         string
-	ContractStartDate  uint64
-	ContractEndDate    uint64
-	ContractTermMonths uint64
-	CostCentre         string
-	CreateDate         uint64
-	CreatedBy          string
-	// LagId // TODO: haven't seen a value other than null
+	AdminLocked           bool
+	AggregationId         json.RawMessage // TODO: haven't seen a value other than null
+	AssociatedIxs         json.RawMessage // TODO: haven't seen a value other than an empty list
+	AssociatedVxcs        []ProductAssociatedVxc
+	AttributeTags         json.RawMessage // TODO: haven't seen a value other than an empty map
+	BuyoutPort            bool
+	Cancelable            bool
+	CompanyName           string
+	CompanyUid            string
+	ContractStartDate     uint64
+	ContractEndDate       uint64
+	ContractTermMonths    uint64
+	CostCentre            string
+	CreateDate            uint64
+	CreatedBy             string
+	DiversityZone         string
+	LagId                 json.RawMessage // TODO: haven't seen a value other than null
 	LagPrimary            bool
 	LiveDate              uint64
 	LocationId            uint64
@@ -116,137 +144,66 @@ type Product struct {
 	ProductName           string
 	ProductType           string
 	ProductUid            string
-	ProvisioningStatus    string
+	ProvisioningStatus    ProvisioningStatus
 	Resources             ProductResources
-	// SecondaryName // TODO: haven't seen a value other than null
-	// TerminateDate // TODO: haven't seen a value other than null
-	// UsageAlgorithm // TODO: haven't seen a value other than null
-	Virtual         bool
-	VxcPermitted    bool
-	VxcAutoApproval bool
+	SecondaryName         json.RawMessage // TODO: haven't seen a value other than null
+	TerminateDate         json.RawMessage // TODO: haven't seen a value other than null
+	UsageAlgorithm        json.RawMessage // TODO: haven't seen a value other than null
+	Virtual               bool
+	VxcPermitted          bool
+	VxcAutoApproval       bool
+	Raw                   RawFields `json:"-"`
 }
 
 type ProductResources struct { // TODO: verify these are the only valid fields
-	// CrossConnect  ProductResourcesCrossConnect `json:"cross_connect"` // TODO: only referenced in https://dev.megaport.com/#general-get-product-list
+	CrossConnect  json.RawMessage `json:"cross_connect"` // TODO: only referenced in https://dev.megaport.com/#general-get-product-list
 	Interface     ProductResourcesInterface
 	VirtualRouter ProductResourcesVirtualRouter `json:"virtual_router"`
 	VLL           ProductResourcesVLL
+	Raw           RawFields `json:"-"`
 }
 
 type ProductResourcesInterface struct {
-	Demarcation  string
-	Description  string
-	Id           uint64 `json:"-"`
-	LoaTemplate  string `json:"loa_template"`
-	Media        string
-	Name         string
-	PortSpeed    uint64 `json:"-"`
-	ResourceName string `json:"resource_name"`
-	ResourceType string `json:"resource_type"`
-	// SupportedSpeeds []uint64 `json:"supported_speeds"` // TODO: only referenced in https://dev.megaport.com/#general-get-product-list
-	Up uint64 `json:"-"`
-}
-
-type productResourcesInterfaceFloats struct {
-	Id        float64
-	PortSpeed float64 `json:"port_speed"`
-	Up        float64
-}
-
-type productResourcesInterface ProductResourcesInterface
-
-func (pr *ProductResourcesInterface) UnmarshalJSON(b []byte) (err error) {
-	v := productResourcesInterface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	*pr = ProductResourcesInterface(v)
-	vf := productResourcesInterfaceFloats{}
-	if err := json.Unmarshal(b, &vf); err != nil {
-		return err
-	}
-	pr.Id = uint64(vf.Id)
-	pr.PortSpeed = uint64(vf.PortSpeed)
-	pr.Up = uint64(vf.Up)
-	return nil
+	Demarcation     string
+	Description     string
+	Id              uint64
+	LoaTemplate     string `json:"loa_template"`
+	Media           string
+	Name            string
+	PortSpeed       uint64   `json:"port_speed"`
+	ResourceName    string   `json:"resource_name"`
+	ResourceType    string   `json:"resource_type"`
+	SupportedSpeeds []uint64 `json:"supported_speeds"` // TODO: only referenced in https://dev.megaport.com/#general-get-product-list
+	Up              uint64
+	Raw             RawFields `json:"-"`
 }
 
 type ProductResourcesVirtualRouter struct {
-	Id           uint64 `json:"-"`
-	McrASN       uint64 `json:"-"`
+	Id           uint64
+	McrASN       uint64 `json:"mcrAsn"`
 	Name         string
 	ResourceName string `json:"resource_name"`
 	ResourceType string `json:"resource_type"`
-	Speed        uint64 `json:"-"`
-}
-
-type productResourcesVirtualRouterFloats struct {
-	Id     float64 `json:"id"`
-	McrASN float64 `json:"mcrAsn"`
-	Speed  float64 `json:"speed"`
-}
-
-type productResourcesVirtualRouter ProductResourcesVirtualRouter
-
-func (pr *ProductResourcesVirtualRouter) UnmarshalJSON(b []byte) (err error) {
-	v := productResourcesVirtualRouter{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	*pr = ProductResourcesVirtualRouter(v)
-	vf := productResourcesVirtualRouterFloats{}
-	if err := json.Unmarshal(b, &vf); err != nil {
-		return err
-	}
-	pr.Id = uint64(vf.Id)
-	pr.McrASN = uint64(vf.McrASN)
-	pr.Speed = uint64(vf.Speed)
-	return nil
+	Speed        uint64
+	Raw          RawFields `json:"-"`
 }
 
 type ProductResourcesVLL struct {
-	AVLan        uint64 `json:"-"`
-	BVLan        uint64 `json:"-"`
+	AVLan        uint64 `json:"a_vlan"`
+	BVLan        uint64 `json:"b_vlan"`
 	Description  string
-	Id           uint64 `json:"-"`
+	Id           uint64
 	Name         string
-	RateLimit    uint64 `json:"-"`
+	RateLimit    uint64 `json:"rate_limit_mbps"`
 	ResourceName string `json:"resource_name"`
 	ResourceType string `json:"resource_type"`
-	Up           uint64 `json:"-"`
-}
-
-type productResourcesVLLFloats struct {
-	AVLan     float64 `json:"a_vlan"`
-	BVLan     float64 `json:"b_vlan"`
-	Id        float64 `json:"id"`
-	RateLimit float64 `json:"rate_limit_mbps"`
-	Up        float64 `json:"up"`
-}
-
-type productResourcesVLL ProductResourcesVLL
-
-func (pr *ProductResourcesVLL) UnmarshalJSON(b []byte) (err error) {
-	v := productResourcesVLL{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	*pr = ProductResourcesVLL(v)
-	vf := productResourcesVLLFloats{}
-	if err := json.Unmarshal(b, &vf); err != nil {
-		return err
-	}
-	pr.AVLan = uint64(vf.AVLan)
-	pr.BVLan = uint64(vf.BVLan)
-	pr.Id = uint64(vf.Id)
-	pr.RateLimit = uint64(vf.RateLimit)
-	pr.Up = uint64(vf.Up)
-	return nil
+	Up           uint64
+	Raw          RawFields `json:"-"`
 }
 
 type ProductAssociatedVxc struct {
-	AdminLocked bool
-	// AttributeTags // TODO: haven't seen a value other than an empty map
+	AdminLocked        bool
+	AttributeTags      json.RawMessage // TODO: haven't seen a value other than an empty map
 	AEnd               ProductAssociatedVxcEnd
 	BEnd               ProductAssociatedVxcEnd
 	Cancelable         bool
@@ -258,90 +215,68 @@ type ProductAssociatedVxc struct {
 	CreateDate         uint64
 	DistanceBand       string
 	Locked             bool
-	// NServiceId // TODO: haven't seen a value other than null
+	NServiceId         json.RawMessage // TODO: haven't seen a value other than null
 	ProductName        string
 	ProductType        string
 	ProductUid         string
-	ProvisioningStatus string
+	ProvisioningStatus ProvisioningStatus
 	RateLimit          uint64
 	Resources          ProductAssociatedVxcResources // TODO: not documented - is the struct here the same as in Product?
 	SecondaryName      string
 	UsageAlgorithm     string
 	VxcApproval        ProductAssociatedVxcApproval
+	Raw                RawFields `json:"-"`
 }
 
 type ProductAssociatedVxcEnd struct {
-	LocationId  uint64
-	Location    string
-	OwnerUid    string
-	ProductUid  string
-	ProductName string
-	Vlan        uint64
-	// SecondaryName // TODO: haven't seen a value other than null
+	LocationId    uint64
+	Location      string
+	OwnerUid      string
+	ProductUid    string
+	ProductName   string
+	Vlan          uint64
+	SecondaryName json.RawMessage // TODO: haven't seen a value other than null
+	Raw           RawFields       `json:"-"`
 }
 
 type ProductAssociatedVxcApproval struct {
-	// Message // TODO: haven't seen a value other than null
-	// NewSpeed // TODO: haven't seen a value other than null
-	// Status // TODO: haven't seen a value other than null
-	// Type // TODO: haven't seen a value other than null
-	// Uid // TODO: haven't seen a value other than null
+	Message  string
+	NewSpeed uint64
+	Status   string
+	Type     string
+	Uid      string
+	Raw      RawFields `json:"-"`
 }
 
 type ProductAssociatedVxcResources struct {
 	AwsVirtualInterface ProductAssociatedVxcResourcesAwsVirtualInterface `json:"csp_connection"`
+	VLL                 ProductResourcesVLL
+	Raw                 RawFields `json:"-"`
 }
 
 type ProductAssociatedVxcResourcesAwsVirtualInterface struct {
 	Account         string
-	AmazonAsn       uint64 `json:"-"`
+	AmazonAsn       uint64
 	AmazonIpAddress string
 	AmazonAddress   string `json:"Amazon_address"`
 	// Amazon_Asn       uint64 `json:"Amazon_asn"`
-	Asn     uint64 `json:"-"`
+	Asn     uint64
 	AuthKey string
 	// Auth_key string `json:"Auth_key"`
 	ConnectType       string
 	CustomerIpAddress string
 	// Customer_address string `json:"Customer_address"`
-	Id           uint64 `json:"-"`
+	Id           uint64
 	Name         string
 	OwnerAccount string
-	PeerAsn      uint64 `json:"-"`
-	// Prefixes // null?
-	ResourceName string `json:"Resource_name"`
-	ResourceType string `json:"Resource_type"`
+	PeerAsn      uint64
+	Prefixes     json.RawMessage // TODO: haven't seen a value other than null
+	ResourceName string          `json:"Resource_name"`
+	ResourceType string          `json:"Resource_type"`
 	Type         string
 	VifId        string `json:"Vif_id"`
-	Vlan         uint64 `json:"-"`
-}
-
-type productAssociatedVxcResourcesAwsVirtualInterfaceFloats struct {
-	AmazonAsn float64 `json:"amazonAsn"`
-	Asn       float64 `json:"asn"`
-	Id        float64 `json:"id"`
-	PeerAsn   float64 `json:"peerAsn"`
-	Vlan      float64 `json:"vlan"`
-}
-
-type productAssociatedVxcResourcesAwsVirtualInterface ProductAssociatedVxcResourcesAwsVirtualInterface
-
-func (pr *ProductAssociatedVxcResourcesAwsVirtualInterface) UnmarshalJSON(b []byte) (err error) {
-	v := productAssociatedVxcResourcesAwsVirtualInterface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	*pr = ProductAssociatedVxcResourcesAwsVirtualInterface(v)
-	vf := productAssociatedVxcResourcesAwsVirtualInterfaceFloats{}
-	if err := json.Unmarshal(b, &vf); err != nil {
-		return err
-	}
-	pr.AmazonAsn = uint64(vf.AmazonAsn)
-	pr.Asn = uint64(vf.Asn)
-	pr.Id = uint64(vf.Id)
-	pr.PeerAsn = uint64(vf.PeerAsn)
-	pr.Vlan = uint64(vf.Vlan)
-	return nil
+	Vlan         uint64
+	Raw          RawFields `json:"-"`
 }
 
 type MegaportCharges struct {
@@ -350,14 +285,36 @@ type MegaportCharges struct {
 	DailySetup           float64
 	Empty                bool
 	FixedRecurringCharge float64
-	// ForceProductChange // TODO: haven't seen a value other than null
-	HourlyRate  float64
-	HourlySetup float64
-	// Key string // TODO: haven't seen a value other than "no key"
-	LongHaulMbpsRate float64
-	MbpsRate         float64
-	MonthlyRate      float64
-	MonthlySetup     float64
-	// PostPaidBaseRate // TODO: haven't seen a value other than "no base rate"
+	ForceProductChange   json.RawMessage // TODO: haven't seen a value other than null
+	HourlyRate           float64
+	HourlySetup          float64
+	Key                  json.RawMessage // TODO: haven't seen a value other than "no key"
+	LongHaulMbpsRate     float64
+	MbpsRate             float64
+	MonthlyRate          float64
+	MonthlySetup         float64
+	PostPaidBaseRate     json.RawMessage // TODO: haven't seen a value other than "no base rate"
+	ProductType          string
+	Raw                  RawFields `json:"-"`
+}
+
+type ManagedCompany struct {
+	AccountName string
+	AccountRef  string
+	CompanyUid  string
+	Raw         RawFields `json:"-"`
+}
+
+type ActivityLog struct {
+	CreateDate  uint64
+	Description string
+	Interface   string
+	LogType     string
+	Message     string
+	ProductName string
 	ProductType string
+	ProductUid  string
+	UserEmail   string
+	UserName    string
+	Raw         RawFields `json:"-"`
 }
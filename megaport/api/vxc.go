@@ -8,6 +8,13 @@ import (
 	"strings"
 )
 
+const (
+	// TODO: these values are not documented and have not been checked against
+	// a recorded response of a pending speed change.
+	VxcApprovalStatusPending = "PENDING"
+	VxcApprovalTypeSpeed     = "SPEED"
+)
+
 type networkDesignInput interface {
 	toPayload() ([]byte, error)
 	productType() string
@@ -163,6 +170,22 @@ func (v *PrivateVxcUpdateInput) toPayload() ([]byte, error) {
 	return json.Marshal(payload)
 }
 
+const (
+	// VlanMin and VlanMax bound the VLANs that the ends of VXCs can use.
+	VlanMin = 2
+	VlanMax = 4093
+)
+
+// ConnectTypeRateLimits lists the only rate limits (in Mbps) that VXCs to the
+// partner ports of some connect types can be ordered with, as those clouds
+// sell connections of fixed capacities. VXCs to other partner ports can have
+// any rate limit up to the speed of the port.
+var ConnectTypeRateLimits = map[string][]uint64{
+	"AWSHC":  {50, 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000},
+	"AZURE":  {50, 100, 200, 500, 1000, 2000, 5000, 10000},
+	"GOOGLE": {50, 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000, 20000, 50000},
+}
+
 func (c *Client) CreatePrivateVxc(v *PrivateVxcCreateInput) (*string, error) {
 	d, err := c.create(v)
 	if err != nil {
@@ -178,8 +201,17 @@ func (c *Client) GetPrivateVxc(uid string) (*ProductAssociatedVxc, error) { // T
 	return d, err
 }
 
-func (c *Client) UpdatePrivateVxc(v *PrivateVxcUpdateInput) error {
-	return c.update(*v.ProductUid, v)
+// UpdatePrivateVxc updates the VXC and, if the update includes a rate limit,
+// returns the speed change approval when the change has been queued for the
+// approval of the B-End owner instead of being applied.
+func (c *Client) UpdatePrivateVxc(v *PrivateVxcUpdateInput) (*ProductAssociatedVxcApproval, error) {
+	if err := c.update(*v.ProductUid, v); err != nil {
+		return nil, err
+	}
+	if v.RateLimit == nil {
+		return nil, nil
+	}
+	return c.getVxcSpeedChange(*v.ProductUid)
 }
 
 func (c *Client) DeletePrivateVxc(uid string) error {
@@ -305,10 +337,37 @@ func (c *Client) GetCloudVxc(uid string) (*ProductAssociatedVxc, error) { // TOD
 	return d, nil
 }
 
-func (c *Client) UpdateCloudVxc(v *CloudVxcUpdateInput) error {
-	return c.update(*v.ProductUid, v)
+// UpdateCloudVxc behaves like UpdatePrivateVxc.
+func (c *Client) UpdateCloudVxc(v *CloudVxcUpdateInput) (*ProductAssociatedVxcApproval, error) {
+	if err := c.update(*v.ProductUid, v); err != nil {
+		return nil, err
+	}
+	if v.RateLimit == nil {
+		return nil, nil
+	}
+	return c.getVxcSpeedChange(*v.ProductUid)
 }
 
 func (c *Client) DeleteCloudVxc(uid string) error {
 	return c.delete(uid)
 }
+
+// PendingRateLimit returns the requested rate limit of a speed change that is
+// awaiting approval, if there is one.
+func (v *ProductAssociatedVxc) PendingRateLimit() (uint64, bool) {
+	if v.VxcApproval.Status == VxcApprovalStatusPending && v.VxcApproval.Type == VxcApprovalTypeSpeed {
+		return v.VxcApproval.NewSpeed, true
+	}
+	return 0, false
+}
+
+func (c *Client) getVxcSpeedChange(uid string) (*ProductAssociatedVxcApproval, error) {
+	d := &ProductAssociatedVxc{}
+	if err := c.get(uid, d); err != nil {
+		return nil, err
+	}
+	if _, ok := d.PendingRateLimit(); !ok {
+		return nil, nil
+	}
+	return &d.VxcApproval, nil
+}
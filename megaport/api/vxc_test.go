@@ -2,6 +2,8 @@ package api
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -58,7 +60,7 @@ func TestPrivateVxcCreateInput_toPayload(t *testing.T) {
 	for i, tc := range testCases {
 		p, err := tc.i.toPayload()
 		if err != nil {
-			t.Errorf("PrivateVxcCreateInput.toPayload (#%d): %w", i, err)
+			t.Errorf("PrivateVxcCreateInput.toPayload (#%d): %v", i, err)
 		}
 		if !bytes.Equal(tc.o, p) {
 			t.Errorf("PrivateVxcCreateInput.toPayload (#%d):\n\tgot      `%s`\n\texpected `%s`", i, p, tc.o)
@@ -112,10 +114,54 @@ func TestPrivateVxcUpdateInput_toPayload(t *testing.T) {
 	for i, tc := range testCases {
 		p, err := tc.i.toPayload()
 		if err != nil {
-			t.Errorf("PrivateVxcUpdateInput.toPayload (#%d): %w", i, err)
+			t.Errorf("PrivateVxcUpdateInput.toPayload (#%d): %v", i, err)
 		}
 		if !bytes.Equal(tc.o, p) {
 			t.Errorf("PrivateVxcUpdateInput.toPayload (#%d):\n\tgot      `%s`\n\texpected `%s`", i, p, tc.o)
 		}
 	}
 }
+
+func TestClient_UpdatePrivateVxc(t *testing.T) {
+	uid := uuid.New().String()
+	rate := uint64(acctest.RandIntRange(1, 10) * 100)
+	testCases := []struct {
+		approval string
+		pending  bool
+	}{
+		{ // 0
+			approval: `{"status":null,"message":null,"uid":null,"type":null,"newSpeed":null}`,
+			pending:  false,
+		},
+		{ // 1
+			approval: `{"status":"PENDING","message":"Speed change requested","uid":"` + uuid.New().String() + `","type":"SPEED","newSpeed":` + strconv.FormatUint(rate, 10) + `.0}`,
+			pending:  true,
+		},
+	}
+	for i, tc := range testCases {
+		c, s := testClientServer(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.URL.Path {
+			case http.MethodPut + " /v2/product/vxc/" + uid:
+				fmt.Fprint(w, `{"message":"VXC updated","data":null}`)
+			case http.MethodGet + " /v2/product/" + uid:
+				fmt.Fprintf(w, `{"data":{"productUid":"%s","rateLimit":50,"vxcApproval":%s}}`, uid, tc.approval)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{}`)
+			}
+		})
+		a, err := c.UpdatePrivateVxc(&PrivateVxcUpdateInput{ProductUid: &uid, RateLimit: &rate})
+		s.Close()
+		if err != nil {
+			t.Errorf("UpdatePrivateVxc (#%d): %v", i, err)
+			continue
+		}
+		if (a != nil) != tc.pending {
+			t.Errorf("UpdatePrivateVxc (#%d): unexpected pending speed change: got %#v, expected pending=%t", i, a, tc.pending)
+			continue
+		}
+		if a != nil && a.NewSpeed != rate {
+			t.Errorf("UpdatePrivateVxc (#%d): unexpected pending speed: got %d, expected %d", i, a.NewSpeed, rate)
+		}
+	}
+}
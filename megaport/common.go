@@ -2,13 +2,23 @@ package megaport
 
 import (
 	"fmt"
+	"log"
 	"net"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
+// productTerms are the contract terms, in months, that products can be ordered
+// for.
+var productTerms = []int{1, 12, 24, 36}
+
 func resourceAttributePrivatePublic() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeString,
@@ -27,6 +37,43 @@ func resourceAttributePrivatePublic() *schema.Schema {
 	}
 }
 
+// resourceAttributeCompanyUid is the company that owns a product, which can be
+// any of the companies managed by the partner the provider is authenticated
+// as. It defaults to the company_uid of the provider. It is not read back from
+// the api, so that only uids that are configured are ever looked up.
+func resourceAttributeCompanyUid() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// dataSourceAttributeCompanyUid is the company whose products a data source
+// looks up, which defaults to the company_uid of the provider.
+func dataSourceAttributeCompanyUid() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// resourceAttributeWaitForStatus is how far a new product has to be
+// provisioned before its creation completes: CONFIGURED, which is what an
+// empty value waits for, or LIVE, which also waits for the product to come
+// up.
+func resourceAttributeWaitForStatus() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: validation.StringInSlice([]string{
+			string(api.ProvisioningStatusConfigured),
+			string(api.ProvisioningStatusLive),
+		}, false),
+	}
+}
+
 func resourceMegaportVxcEndElem() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -44,19 +91,76 @@ func resourceMegaportVxcEndElem() *schema.Resource {
 	}
 }
 
+// validateCIDRAddress accepts an address with the prefix length of its
+// network, such as the 169.254.0.1/30 peer addresses of AWS VXCs, in its
+// canonical form.
 func validateCIDRAddress(v interface{}, k string) (warns []string, errs []error) {
 	vv := v.(string)
-	_, ipnet, err := net.ParseCIDR(vv)
+	ip, ipnet, err := net.ParseCIDR(vv)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("%q is not a valid CIDR: %s", k, err))
 		return
 	}
-	if ipnet == nil || vv != ipnet.String() {
+	if ipnet == nil || vv != (&net.IPNet{IP: ip, Mask: ipnet.Mask}).String() {
 		errs = append(errs, fmt.Errorf("%q is not a valid CIDR", k))
 	}
 	return
 }
 
+func validateDuration(v interface{}, k string) (warns []string, errs []error) {
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q is not a valid duration: %s", k, err))
+		return
+	}
+	if d < 0 {
+		errs = append(errs, fmt.Errorf("%q cannot be negative", k))
+	}
+	return
+}
+
+// formatMillis formats a timestamp of the api, in milliseconds since the
+// epoch, as RFC3339. Timestamps that are not set are left empty.
+func formatMillis(ms uint64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.Unix(0, int64(ms)*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+// dataSourceSchema merges the arguments a data source is looked up by with the
+// attributes it exports. Arguments that are also attributes become computed,
+// so that they are set when the data source is looked up by other arguments.
+func dataSourceSchema(args, attrs map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for k, v := range attrs {
+		s[k] = v
+	}
+	for k, v := range args {
+		if _, ok := attrs[k]; ok {
+			v.Computed = true
+		}
+		s[k] = v
+	}
+	return s
+}
+
+// setAttributes sets every attribute of a flattened object, in a stable order
+// so that errors are reported consistently.
+func setAttributes(d *schema.ResourceData, attrs map[string]interface{}) error {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := d.Set(k, attrs[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func flattenVxcEnd(v api.ProductAssociatedVxcEnd) []interface{} {
 	return []interface{}{map[string]interface{}{
 		"product_uid": v.ProductUid,
@@ -64,15 +168,268 @@ func flattenVxcEnd(v api.ProductAssociatedVxcEnd) []interface{} {
 	}}
 }
 
-func isResourceDeleted(provisioningStatus string) bool {
-	switch provisioningStatus {
-	case api.ProductStatusCancelled:
-		fallthrough
-	case api.ProductStatusCancelledParent:
-		fallthrough
-	case api.ProductStatusDecommissioned:
-		return true
-	default:
-		return false
+// vxcEnd is the product or partner port at one end of a VXC.
+type vxcEnd struct {
+	uid         string
+	name        string
+	speed       uint64
+	connectType string
+	vxcs        []api.ProductAssociatedVxc
+}
+
+// getVxcEnd returns nil for products that cannot be found, such as the ports
+// of other companies, leaving their checks to the api.
+func getVxcEnd(cfg *Config, c *api.Client, uid string) (*vxcEnd, error) {
+	pps, err := cfg.Catalog.PartnerPorts()
+	if err != nil {
+		return nil, err
+	}
+	for _, pp := range pps {
+		if pp.ProductUid == uid {
+			return &vxcEnd{uid: uid, name: pp.Title, speed: pp.Speed, connectType: pp.ConnectType}, nil
+		}
+	}
+	p, err := c.GetPort(uid)
+	if err == api.ErrNotFound {
+		log.Printf("getVxcEnd: cannot find %s, leaving its checks to the api", uid)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &vxcEnd{uid: uid, name: p.ProductName, speed: p.PortSpeed, vxcs: p.AssociatedVxcs}, nil
+}
+
+// checkRateLimit checks the rate limit of the VXC vxcUid against the speed of
+// the end and the rate limits its connect type permits. Ports can be
+// oversubscribed, so a port whose VXCs add up to more than its speed is only
+// warned about, see oversubscription.
+func (e *vxcEnd) checkRateLimit(vxcUid, end string, rateLimit uint64) error {
+	if rateLimit > e.speed {
+		return fmt.Errorf("rate_limit of %d Mbps exceeds the %d Mbps speed of %s (%s)", rateLimit, e.speed, e.name, end)
+	}
+	if ls, ok := api.ConnectTypeRateLimits[e.connectType]; ok {
+		permitted := false
+		available := make([]string, len(ls))
+		for i, l := range ls {
+			permitted = permitted || l == rateLimit
+			available[i] = strconv.FormatUint(l, 10)
+		}
+		if !permitted {
+			return fmt.Errorf("rate_limit of %d Mbps is not available for %s VXCs, available rate limits are %s Mbps", rateLimit, e.connectType, strings.Join(available, ", "))
+		}
+	}
+	if w := e.oversubscription(vxcUid, rateLimit); w != "" {
+		log.Printf("[WARN] %s", w)
+	}
+	return nil
+}
+
+// oversubscription returns a warning when the rate limits of the VXCs of the
+// end, with the VXC vxcUid at rateLimit, add up to more than its speed.
+func (e *vxcEnd) oversubscription(vxcUid string, rateLimit uint64) string {
+	total := rateLimit
+	for _, v := range e.vxcs {
+		if v.ProductUid != vxcUid && !v.ProvisioningStatus.IsTerminal() {
+			total += v.RateLimit
+		}
+	}
+	if total <= e.speed {
+		return ""
+	}
+	return fmt.Sprintf("the VXCs of %s add up to %d Mbps, which exceeds its speed of %d Mbps", e.name, total, e.speed)
+}
+
+// checkVlan checks that no VXC other than vxcUid uses the VLAN on the end.
+func (e *vxcEnd) checkVlan(vxcUid, end string, vlan uint64) error {
+	for _, v := range e.vxcs {
+		if v.ProductUid == vxcUid || v.ProvisioningStatus.IsTerminal() {
+			continue
+		}
+		if (v.AEnd.ProductUid == e.uid && v.AEnd.Vlan == vlan) || (v.BEnd.ProductUid == e.uid && v.BEnd.Vlan == vlan) {
+			return fmt.Errorf("VLAN %d of %s (%s) is already in use by %s", vlan, e.name, end, v.ProductName)
+		}
+	}
+	return nil
+}
+
+// resourceMegaportVxcCustomizeDiff checks the rate limit and the VLANs of a
+// VXC against both of its ends, when they are new or have changed. VLANs are
+// only checked against the VXCs that already exist, since the plan of one VXC
+// cannot see the others: two new VXCs of a configuration with the same VLAN
+// still pass the plan, and neither is checked against megaport_vlan_allocation
+// reservations. VXCs that take their VLANs from allocations get distinct ones.
+func resourceMegaportVxcCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	cfg := m.(*Config)
+	for _, end := range []string{"a_end", "b_end"} {
+		uidKey, vlanKey := end+".0.product_uid", end+".0.vlan"
+		if !d.NewValueKnown(uidKey) {
+			continue
+		}
+		changed := func(k string) bool {
+			return d.Id() == "" || d.HasChange(k) || d.HasChange(uidKey)
+		}
+		checkRateLimit := d.NewValueKnown("rate_limit") && changed("rate_limit")
+		vlan, _ := d.Get(vlanKey).(int)
+		checkVlan := vlan != 0 && d.NewValueKnown(vlanKey) && changed(vlanKey)
+		if !checkRateLimit && !checkVlan {
+			continue
+		}
+		c, err := cfg.client(d)
+		if err != nil {
+			return err
+		}
+		e, err := getVxcEnd(cfg, c, d.Get(uidKey).(string))
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			continue
+		}
+		if checkRateLimit {
+			if err := e.checkRateLimit(d.Id(), end, uint64(d.Get("rate_limit").(int))); err != nil {
+				return err
+			}
+		}
+		if checkVlan {
+			if err := e.checkVlan(d.Id(), end, uint64(vlan)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// suppressPendingRateLimitDiff hides the rate limit diff of a VXC while the
+// configured rate limit is awaiting the approval of the B-End owner.
+func suppressPendingRateLimitDiff(k, old, new string, d *schema.ResourceData) bool {
+	p := d.Get("pending_rate_limit").(int)
+	return p != 0 && strconv.Itoa(p) == new
+}
+
+// waitForVxcSpeedChange waits for the B-End owner to approve a speed change.
+// A change that is still awaiting approval when the wait times out is an
+// error, so that the apply does not report a rate limit that is not in effect.
+func waitForVxcSpeedChange(get func(string) (*api.ProductAssociatedVxc, error), uid string, rateLimit uint64, timeout time.Duration) error {
+	scc := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"applied"},
+		Refresh: func() (interface{}, string, error) {
+			v, err := get(uid)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, ok := v.PendingRateLimit(); ok {
+				return v, "pending", nil
+			}
+			if v.RateLimit != rateLimit {
+				return nil, "", fmt.Errorf("speed change of %s to %d Mbps was not approved, rate limit is %d Mbps", uid, rateLimit, v.RateLimit)
+			}
+			return v, "applied", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+	_, err := scc.WaitForState()
+	if _, ok := err.(*resource.TimeoutError); ok {
+		return fmt.Errorf("speed change of %s to %d Mbps is still awaiting the approval of the B-End owner after %s, it is kept in pending_rate_limit and applied once approved", uid, rateLimit, timeout)
+	}
+	return err
+}
+
+func portProvisioningStatus(c *api.Client) func(string) (api.ProvisioningStatus, error) {
+	return func(uid string) (api.ProvisioningStatus, error) {
+		p, err := c.GetPort(uid)
+		if err != nil {
+			return "", err
+		}
+		return p.ProvisioningStatus, nil
+	}
+}
+
+func vxcProvisioningStatus(get func(string) (*api.ProductAssociatedVxc, error)) func(string) (api.ProvisioningStatus, error) {
+	return func(uid string) (api.ProvisioningStatus, error) {
+		v, err := get(uid)
+		if err != nil {
+			return "", err
+		}
+		return v.ProvisioningStatus, nil
+	}
+}
+
+// waitForProvisioningStatus waits for a new product to reach the status set in
+// wait_for_status, or any status after it. A product that is cancelled while
+// it is being provisioned fails the wait.
+func waitForProvisioningStatus(get func(string) (api.ProvisioningStatus, error), d *schema.ResourceData) error {
+	target := api.ProvisioningStatus(d.Get("wait_for_status").(string))
+	if target == "" {
+		target = api.ProvisioningStatusConfigured
+	}
+	pending := []string{
+		string(api.ProvisioningStatusNew),
+		string(api.ProvisioningStatusDesign),
+		string(api.ProvisioningStatusDeployable),
+	}
+	targets := []string{string(api.ProvisioningStatusLive)}
+	if target == api.ProvisioningStatusConfigured {
+		targets = append(targets, string(api.ProvisioningStatusConfigured))
+	} else {
+		pending = append(pending, string(api.ProvisioningStatusConfigured))
+	}
+	scc := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  targets,
+		Refresh: func() (interface{}, string, error) {
+			s, err := get(d.Id())
+			if err != nil {
+				return nil, "", err
+			}
+			return s, string(s), nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := scc.WaitForState(); err != nil {
+		return fmt.Errorf("waiting for %s to become %s: %v", d.Id(), target, err)
+	}
+	return nil
+}
+
+// waitForProvisioningStatusTerminal waits for a cancelled product to be
+// removed. Products that cannot be found any more are treated as removed.
+func waitForProvisioningStatusTerminal(get func(string) (api.ProvisioningStatus, error), d *schema.ResourceData) error {
+	pending := []string{}
+	for _, s := range []api.ProvisioningStatus{
+		api.ProvisioningStatusNew,
+		api.ProvisioningStatusDesign,
+		api.ProvisioningStatusDeployable,
+		api.ProvisioningStatusConfigured,
+		api.ProvisioningStatusLive,
+		api.ProvisioningStatusDecommissioning,
+	} {
+		pending = append(pending, string(s))
+	}
+	scc := &resource.StateChangeConf{
+		Pending: pending,
+		Target: []string{
+			string(api.ProvisioningStatusDecommissioned),
+			string(api.ProvisioningStatusCancelled),
+			string(api.ProvisioningStatusCancelledParent),
+		},
+		Refresh: func() (interface{}, string, error) {
+			s, err := get(d.Id())
+			if err == api.ErrNotFound {
+				s = api.ProvisioningStatusDecommissioned
+			} else if err != nil {
+				return nil, "", err
+			}
+			return s, string(s), nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := scc.WaitForState(); err != nil {
+		return fmt.Errorf("waiting for %s to be removed: %v", d.Id(), err)
 	}
+	return nil
 }
@@ -5,12 +5,17 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"testing"
 	"text/template"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
 )
 
 var (
@@ -56,7 +61,7 @@ func testAccCheckResourceDestroy(s *terraform.State) error {
 			if err != nil {
 				return err
 			}
-			if v != nil && !isResourceDeleted(v.ProvisioningStatus) {
+			if v != nil && !v.ProvisioningStatus.IsTerminal() {
 				return fmt.Errorf("testAccCheckResourceDestroy: %q (%s) has not been destroyed", n, rs.Primary.ID)
 			}
 		case "megaport_aws_vxc":
@@ -64,9 +69,34 @@ func testAccCheckResourceDestroy(s *terraform.State) error {
 			if err != nil {
 				return err
 			}
-			if v != nil && !isResourceDeleted(v.ProvisioningStatus) {
+			if v != nil && !v.ProvisioningStatus.IsTerminal() {
 				return fmt.Errorf("testAccCheckResourceDestroy: %q (%s) has not been destroyed", n, rs.Primary.ID)
 			}
+		case "megaport_private_vxc":
+			v, err := cfg.Client.GetPrivateVxc(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if v != nil && !v.ProvisioningStatus.IsTerminal() {
+				return fmt.Errorf("testAccCheckResourceDestroy: %q (%s) has not been destroyed", n, rs.Primary.ID)
+			}
+		case "megaport_vlan_allocation":
+			vlan, err := strconv.ParseUint(rs.Primary.Attributes["vlan"], 10, 64)
+			if err != nil {
+				return err
+			}
+			p, err := cfg.Client.GetPort(rs.Primary.Attributes["product_uid"])
+			if err == api.ErrNotFound || (err == nil && p.ProvisioningStatus.IsTerminal()) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			for _, v := range p.AssociatedVxcs {
+				if !v.ProvisioningStatus.IsTerminal() && (v.AEnd.Vlan == vlan || v.BEnd.Vlan == vlan) {
+					return fmt.Errorf("testAccCheckResourceDestroy: VLAN %d of %q (%s) is still in use by %s", vlan, n, rs.Primary.ID, v.ProductName)
+				}
+			}
 		default:
 			return fmt.Errorf("testAccCheckResourceDestroy: not implemented, cannot check %q (%s)", n, rs.Primary.ID)
 		}
@@ -131,3 +161,163 @@ func testAccLogConfig(step int, cfg string) {
 	}
 	fmt.Printf("+++ CONFIG (step %d):\n%s\n", step, strings.Join(l, "\n"))
 }
+
+func TestValidateCIDRAddress(t *testing.T) {
+	for i, tc := range []struct {
+		v  string
+		ok bool
+	}{
+		{"169.254.0.1/30", true},
+		{"10.0.0.0/30", true},
+		{"2001:db8::1/64", true},
+		{"10.0.0.1", false},
+		{"10.0.0.1/33", false},
+		{"010.0.0.1/30", false},
+		{"2001:DB8::1/64", false},
+	} {
+		_, errs := validateCIDRAddress(tc.v, "foo")
+		if (len(errs) == 0) != tc.ok {
+			t.Errorf("validateCIDRAddress (#%d): unexpected result for %q: %v", i, tc.v, errs)
+		}
+	}
+}
+
+func TestWaitForProvisioningStatus(t *testing.T) {
+	for i, tc := range []struct {
+		waitFor  string
+		status   api.ProvisioningStatus
+		terminal bool
+		err      error
+		ok       bool
+	}{
+		{"", api.ProvisioningStatusConfigured, false, nil, true},
+		{"", api.ProvisioningStatusLive, false, nil, true},
+		{"LIVE", api.ProvisioningStatusLive, false, nil, true},
+		{"", api.ProvisioningStatusCancelled, false, nil, false},
+		{"", "", false, api.ErrNotFound, false},
+		{"", api.ProvisioningStatusDecommissioned, true, nil, true},
+		{"", api.ProvisioningStatusCancelledParent, true, nil, true},
+		{"", "", true, api.ErrNotFound, true},
+	} {
+		d := schema.TestResourceDataRaw(t, resourceMegaportPort().Schema, map[string]interface{}{
+			"wait_for_status": tc.waitFor,
+		})
+		d.SetId("foo")
+		get := func(uid string) (api.ProvisioningStatus, error) {
+			return tc.status, tc.err
+		}
+		wait := waitForProvisioningStatus
+		if tc.terminal {
+			wait = waitForProvisioningStatusTerminal
+		}
+		if err := wait(get, d); (err == nil) != tc.ok {
+			t.Errorf("waitForProvisioningStatus (#%d): unexpected result: %v", i, err)
+		}
+	}
+}
+
+func TestWaitForVxcSpeedChange(t *testing.T) {
+	for i, tc := range []struct {
+		vxc api.ProductAssociatedVxc
+		err string
+	}{
+		{api.ProductAssociatedVxc{RateLimit: 200}, ""},
+		{api.ProductAssociatedVxc{RateLimit: 100}, "was not approved"},
+		{api.ProductAssociatedVxc{RateLimit: 100, VxcApproval: api.ProductAssociatedVxcApproval{
+			NewSpeed: 200,
+			Status:   api.VxcApprovalStatusPending,
+			Type:     api.VxcApprovalTypeSpeed,
+		}}, "still awaiting the approval"},
+	} {
+		get := func(uid string) (*api.ProductAssociatedVxc, error) {
+			v := tc.vxc
+			return &v, nil
+		}
+		err := waitForVxcSpeedChange(get, "foo", 200, 100*time.Millisecond)
+		if tc.err == "" && err != nil {
+			t.Errorf("waitForVxcSpeedChange (#%d): unexpected error: %v", i, err)
+		}
+		if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("waitForVxcSpeedChange (#%d): expected an error containing %q, got %v", i, tc.err, err)
+		}
+	}
+}
+
+func TestResourceMegaportVxc_customizeDiff(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	cfg := testProviderConfig(t, s)
+	a := s.AddPort(&api.Product{ProductName: "a", LocationId: megaporttest.LocationTelehouseNorth, PortSpeed: 1000})
+	b := s.AddPort(&api.Product{ProductName: "b", LocationId: megaporttest.LocationTelehouseNorth, PortSpeed: 10000})
+	hc := "5b7c2a4e-0d4f-4c1e-8a77-3f2d9e6b1c01"
+	s.AddPartnerPort(&api.Megaport{
+		ConnectType:  "AWSHC",
+		LocationId:   megaporttest.LocationTelehouseNorth,
+		ProductUid:   hc,
+		Speed:        10000,
+		Title:        "Amazon Web Services Hosted Connection",
+		VxcPermitted: true,
+	})
+	existing, err := cfg.Client.CreatePrivateVxc(&api.PrivateVxcCreateInput{
+		ProductUidA: api.String(a),
+		ProductUidB: api.String(b),
+		Name:        api.String("existing"),
+		RateLimit:   api.Uint64(uint64(600)),
+		VlanA:       api.Uint64(uint64(100)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := getVxcEnd(cfg, cfg.Client, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range []struct {
+		vxcUid    string
+		rateLimit uint64
+		warning   string
+	}{
+		{"", 400, ""},
+		{"", 500, "the VXCs of a add up to 1100 Mbps, which exceeds its speed of 1000 Mbps"},
+		{*existing, 1000, ""},
+	} {
+		if w := e.oversubscription(tc.vxcUid, tc.rateLimit); w != tc.warning {
+			t.Errorf("vxcEnd.oversubscription (#%d): got %q, expected %q", i, w, tc.warning)
+		}
+	}
+	if e, err := getVxcEnd(cfg, cfg.Client, "foo"); e != nil || err != nil {
+		t.Errorf("getVxcEnd: expected nothing for a product that does not exist, got %v, %v", e, err)
+	}
+	for i, tc := range []struct {
+		r         *schema.Resource
+		vlan      int
+		b         map[string]interface{}
+		rateLimit int
+		err       string
+	}{
+		{resourceMegaportPrivateVxc(), 0, map[string]interface{}{"product_uid": b}, 500, ""},
+		{resourceMegaportPrivateVxc(), 0, map[string]interface{}{"product_uid": b}, 2000, "exceeds the 1000 Mbps speed of a (a_end)"},
+		{resourceMegaportPrivateVxc(), 101, map[string]interface{}{"product_uid": b}, 100, ""},
+		{resourceMegaportPrivateVxc(), 100, map[string]interface{}{"product_uid": b}, 100, "VLAN 100 of a (a_end) is already in use by existing"},
+		{resourceMegaportAwsVxc(), 0, map[string]interface{}{"product_uid": hc, "aws_account_id": "123456789012", "customer_asn": 65000, "type": "private"}, 500, ""},
+		{resourceMegaportAwsVxc(), 0, map[string]interface{}{"product_uid": hc, "aws_account_id": "123456789012", "customer_asn": 65000, "type": "private"}, 250, "not available for AWSHC VXCs"},
+	} {
+		aEnd := map[string]interface{}{"product_uid": a}
+		if tc.vlan != 0 {
+			aEnd["vlan"] = tc.vlan
+		}
+		raw := map[string]interface{}{
+			"name":       "foo",
+			"rate_limit": tc.rateLimit,
+			"a_end":      []interface{}{aEnd},
+			"b_end":      []interface{}{tc.b},
+		}
+		_, err := tc.r.Diff(nil, terraform.NewResourceConfigRaw(raw), cfg)
+		if tc.err == "" && err != nil {
+			t.Errorf("resourceMegaportVxcCustomizeDiff (#%d): unexpected error: %v", i, err)
+		}
+		if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("resourceMegaportVxcCustomizeDiff (#%d): expected an error containing %q, got %v", i, tc.err, err)
+		}
+	}
+}
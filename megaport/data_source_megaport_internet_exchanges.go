@@ -0,0 +1,151 @@
+package megaport
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportInternetExchanges() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportInternetExchangesRead,
+
+		Schema: map[string]*schema.Schema{
+			"location_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"asn": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"internet_exchanges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceMegaportInternetExchangeAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMegaportInternetExchangeAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"asn": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"group_metro": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"network_region": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"ecix": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"primary_ipv4": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"primary_ipv6": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"secondary_ipv4": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"secondary_ipv6": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// dataSourceMegaportInternetExchangesRead returns the exchanges that an ix
+// can connect to at location_id, sorted by name. The name is the network
+// service type of an ix order.
+func dataSourceMegaportInternetExchangesRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	locationId := uint64(d.Get("location_id").(int))
+	ixs, err := cfg.Client.GetInternetExchanges(locationId)
+	if err != nil {
+		return err
+	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
+	filtered := []*api.InternetExchange{}
+	for _, ix := range ixs {
+		if nr != nil && !nr.MatchString(ix.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("asn"); ok && ix.ASN != uint64(v.(int)) {
+			continue
+		}
+		filtered = append(filtered, ix)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	names := []string{strconv.FormatUint(locationId, 10)}
+	ret := make([]interface{}, len(filtered))
+	for i, ix := range filtered {
+		names = append(names, ix.Name)
+		ret[i] = flattenInternetExchange(ix)
+	}
+	if err := d.Set("internet_exchanges", ret); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(names, ","))))
+	return nil
+}
+
+func flattenInternetExchange(ix *api.InternetExchange) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           ix.Name,
+		"asn":            int(ix.ASN),
+		"description":    ix.Description,
+		"group_metro":    ix.GroupMetro,
+		"network_region": ix.NetworkRegion,
+		"state":          ix.State,
+		"ecix":           ix.ECIX,
+		"primary_ipv4":   ix.PrimaryIPv4.Value,
+		"primary_ipv6":   ix.PrimaryIPv6.Value,
+		"secondary_ipv4": ix.SecondaryIPv4.Value,
+		"secondary_ipv6": ix.SecondaryIPv6.Value,
+	}
+}
@@ -0,0 +1,33 @@
+package megaport
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMegaportInternetExchanges_basic(t *testing.T) {
+	cfg, err := testAccGetConfig("megaport_internet_exchanges_basic", map[string]interface{}{
+		"location": "Telehouse North",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.megaport_internet_exchanges.all", "internet_exchanges.1.name"),
+					resource.TestCheckResourceAttr("data.megaport_internet_exchanges.lon1", "internet_exchanges.#", "1"),
+					resource.TestCheckResourceAttrSet("data.megaport_internet_exchanges.lon1", "internet_exchanges.0.asn"),
+					resource.TestCheckResourceAttrSet("data.megaport_internet_exchanges.lon1", "internet_exchanges.0.primary_ipv4"),
+				),
+			},
+		},
+	})
+}
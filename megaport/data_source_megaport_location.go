@@ -2,7 +2,6 @@ package megaport
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"strconv"
 
@@ -11,53 +10,55 @@ import (
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
-var (
-	megaportLocations []*api.Location
-)
-
 func dataSourceMegaportLocation() *schema.Resource {
+	attrs := dataSourceMegaportLocationAttributes()
+	delete(attrs, "id")
 	return &schema.Resource{
 		Read: dataSourceMegaportLocationRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(map[string]*schema.Schema{
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.ValidateRegexp,
 			},
-		},
-	}
-}
-
-func dataSourceUpdateLocations(c *api.Client) error {
-	megaportMutexKV.Lock("locations")
-	defer megaportMutexKV.Unlock("locations")
-	if megaportLocations != nil {
-		return nil
-	}
-	log.Printf("Updating location list")
-	loc, err := c.GetLocations()
-	if err != nil {
-		return err
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"site_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		}, attrs),
 	}
-	megaportLocations = loc
-	return nil
 }
 
 func dataSourceMegaportLocationRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	if err := dataSourceUpdateLocations(cfg.Client); err != nil {
+	locations, err := cfg.Catalog.Locations()
+	if err != nil {
 		return err
 	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
 	var filtered []*api.Location
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		nr := regexp.MustCompile(nameRegex.(string))
-		for _, loc := range megaportLocations {
-			if nr.MatchString(loc.Name) {
-				filtered = append(filtered, loc)
-			}
+	for _, loc := range locations {
+		if nr != nil && !nr.MatchString(loc.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("name"); ok && loc.Name != v.(string) {
+			continue
 		}
+		if v, ok := d.GetOk("site_code"); ok && loc.SiteCode != v.(string) {
+			continue
+		}
+		filtered = append(filtered, loc)
 	}
 	if len(filtered) < 1 {
 		return fmt.Errorf("No locations were found.")
@@ -66,5 +67,55 @@ func dataSourceMegaportLocationRead(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Multiple locations were found. Please use a more specific query.")
 	}
 	d.SetId(strconv.FormatUint(filtered[0].Id, 10))
-	return nil
+	attrs := flattenLocation(filtered[0])
+	delete(attrs, "id")
+	return setAttributes(d, attrs)
+}
+
+func dataSourceAttributeDiversityZones() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"port_speeds": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeInt},
+				},
+				"mcr_speeds": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeInt},
+				},
+			},
+		},
+	}
+}
+
+// flattenLocationDiversityZones lists every zone of the location, with the
+// speeds of the ports and MCRs that can be ordered in it.
+func flattenLocationDiversityZones(dz api.LocationDiversityZones) []interface{} {
+	names, zones := dz.Zones()
+	ret := make([]interface{}, len(zones))
+	for i, z := range zones {
+		ret[i] = map[string]interface{}{
+			"name":        names[i],
+			"port_speeds": flattenSpeeds(z.MegaportSpeedMbps),
+			"mcr_speeds":  flattenSpeeds(z.McrSpeedMbps),
+		}
+	}
+	return ret
+}
+
+func flattenSpeeds(ss []uint64) []interface{} {
+	ret := make([]interface{}, len(ss))
+	for i, s := range ss {
+		ret[i] = int(s)
+	}
+	return ret
 }
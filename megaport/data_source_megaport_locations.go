@@ -0,0 +1,268 @@
+package megaport
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportLocations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportLocationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"country": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"metro": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"market": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"port_speed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"mcr_speed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"locations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceMegaportLocationAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// dataSourceMegaportLocationAttributes describes every field of a location.
+// Speeds are in Mbps, like the speed of megaport_port, even though the api
+// lists the speeds of ports in Gbps.
+func dataSourceMegaportLocationAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"site_code": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"campus": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"country": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"metro": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"market": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"network_region": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"live_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"latitude": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"longitude": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"vrouter_available": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"address": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"street": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"suburb": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"city": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"state": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"postcode": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"country": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"products": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"mcr": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"mcr_version": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"mcr1_speeds": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeInt},
+					},
+					"mcr2_speeds": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeInt},
+					},
+					"port_speeds": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeInt},
+					},
+				},
+			},
+		},
+		"diversity_zones": dataSourceAttributeDiversityZones(),
+	}
+}
+
+func dataSourceMegaportLocationsRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	locations, err := cfg.Catalog.Locations()
+	if err != nil {
+		return err
+	}
+	q := &api.LocationQuery{}
+	if v, ok := d.GetOk("name_regex"); ok {
+		q.Name = regexp.MustCompile(v.(string))
+	}
+	for k, f := range map[string]**string{
+		"country": &q.Country,
+		"metro":   &q.Metro,
+		"market":  &q.Market,
+		"status":  &q.Status,
+	} {
+		if v, ok := d.GetOk(k); ok {
+			*f = api.String(v)
+		}
+	}
+	if v, ok := d.GetOk("port_speed"); ok {
+		q.PortSpeed = api.Uint64FromInt(v)
+	}
+	if v, ok := d.GetOk("mcr_speed"); ok {
+		q.MCR2Speed = api.Uint64FromInt(v)
+	}
+	filtered := q.Filter(locations)
+	ids := make([]string, len(filtered))
+	ret := make([]interface{}, len(filtered))
+	for i, l := range filtered {
+		ids[i] = strconv.FormatUint(l.Id, 10)
+		ret[i] = flattenLocation(l)
+	}
+	if err := d.Set("locations", ret); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	return nil
+}
+
+func flattenLocation(l *api.Location) map[string]interface{} {
+	portSpeeds := make([]uint64, len(l.Products.Megaport))
+	for i, s := range l.Products.Megaport {
+		portSpeeds[i] = s * 1000
+	}
+	return map[string]interface{}{
+		"id":                int(l.Id),
+		"name":              l.Name,
+		"site_code":         l.SiteCode,
+		"campus":            l.Campus,
+		"country":           l.Country,
+		"metro":             l.Metro,
+		"market":            l.Market,
+		"network_region":    l.NetworkRegion,
+		"status":            l.Status,
+		"live_date":         formatMillis(l.LiveDate),
+		"latitude":          l.Latitude,
+		"longitude":         l.Longitude,
+		"vrouter_available": l.VRouterAvailable,
+		"address": []interface{}{map[string]interface{}{
+			"street":   l.Address.Street,
+			"suburb":   l.Address.Suburb,
+			"city":     l.Address.City,
+			"state":    l.Address.State,
+			"postcode": l.Address.Postcode,
+			"country":  l.Address.Country,
+		}},
+		"products": []interface{}{map[string]interface{}{
+			"mcr":         l.Products.MCR,
+			"mcr_version": int(l.Products.MCRVersion),
+			"mcr1_speeds": flattenSpeeds(l.Products.MCR1),
+			"mcr2_speeds": flattenSpeeds(l.Products.MCR2),
+			"port_speeds": flattenSpeeds(portSpeeds),
+		}},
+		"diversity_zones": flattenLocationDiversityZones(l.DiversityZones),
+	}
+}
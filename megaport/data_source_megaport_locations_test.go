@@ -0,0 +1,69 @@
+package megaport
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMegaportLocations_basic(t *testing.T) {
+	cfg, err := testAccGetConfig("megaport_locations_basic", map[string]interface{}{
+		"metro": "London",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLocations("data.megaport_locations.foo", "London", 10000),
+					resource.TestCheckResourceAttrSet("data.megaport_locations.foo", "locations.0.site_code"),
+					resource.TestCheckResourceAttrSet("data.megaport_locations.foo", "locations.0.address.0.city"),
+					resource.TestCheckResourceAttrPair("data.megaport_location.foo", "id", "data.megaport_locations.foo", "locations.0.id"),
+					resource.TestCheckResourceAttrPair("data.megaport_location.foo", "name", "data.megaport_locations.foo", "locations.0.name"),
+					resource.TestCheckResourceAttr("data.megaport_location.foo", "metro", "London"),
+					resource.TestCheckResourceAttrPair("data.megaport_location.foo", "address.0.street", "data.megaport_locations.foo", "locations.0.address.0.street"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLocations(n, metro string, speed int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", n)
+		}
+		count, _ := strconv.Atoi(rs.Primary.Attributes["locations.#"])
+		if count == 0 {
+			return fmt.Errorf("no locations were found")
+		}
+		for i := 0; i < count; i++ {
+			prefix := fmt.Sprintf("locations.%d.", i)
+			if m := rs.Primary.Attributes[prefix+"metro"]; m != metro {
+				return fmt.Errorf("location %d is in %s, expected %s", i, m, metro)
+			}
+			found := false
+			speeds, _ := strconv.Atoi(rs.Primary.Attributes[prefix+"products.0.port_speeds.#"])
+			for j := 0; j < speeds; j++ {
+				if rs.Primary.Attributes[fmt.Sprintf("%sproducts.0.port_speeds.%d", prefix, j)] == strconv.Itoa(speed) {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("location %d does not offer %d Mbps ports", i, speed)
+			}
+		}
+		return nil
+	}
+}
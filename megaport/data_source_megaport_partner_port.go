@@ -2,7 +2,6 @@ package megaport
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -10,15 +9,23 @@ import (
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
-var (
-	megaportPartnerPorts []*api.Megaport
-)
-
 func dataSourceMegaportPartnerPort() *schema.Resource {
+	attrs := dataSourceMegaportPartnerPortAttributes()
+	delete(attrs, "vxc_permitted")
 	return &schema.Resource{
 		Read: dataSourceMegaportPartnerPortRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(map[string]*schema.Schema{
+			"product_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -41,31 +48,16 @@ func dataSourceMegaportPartnerPort() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
-		},
+		}, attrs),
 	}
 }
 
-func dataSourceUpdatePartnerPorts(c *api.Client) error {
-	megaportMutexKV.Lock("partner_ports")
-	defer megaportMutexKV.Unlock("partner_ports")
-	if megaportPartnerPorts != nil {
-		return nil
-	}
-	log.Printf("Updating partner port list")
-	pp, err := c.GetMegaports() // TODO: rename in api
-	if err != nil {
-		return err
-	}
-	megaportPartnerPorts = pp
-	return nil
-}
-
 func dataSourceMegaportPartnerPortRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	if err := dataSourceUpdatePartnerPorts(cfg.Client); err != nil {
+	unfiltered, err := cfg.Catalog.PartnerPorts()
+	if err != nil {
 		return err
 	}
-	unfiltered := megaportPartnerPorts
 	filtered := []*api.Megaport{}
 	vp := d.Get("vxc_permitted")
 	for _, port := range unfiltered {
@@ -83,6 +75,24 @@ func dataSourceMegaportPartnerPortRead(d *schema.ResourceData, m interface{}) er
 			}
 		}
 	}
+	if uid, ok := d.GetOk("product_uid"); ok {
+		unfiltered = filtered
+		filtered = []*api.Megaport{}
+		for _, port := range unfiltered {
+			if port.ProductUid == uid.(string) {
+				filtered = append(filtered, port)
+			}
+		}
+	}
+	if title, ok := d.GetOk("title"); ok {
+		unfiltered = filtered
+		filtered = []*api.Megaport{}
+		for _, port := range unfiltered {
+			if port.Title == title.(string) {
+				filtered = append(filtered, port)
+			}
+		}
+	}
 	if ct, ok := d.GetOk("connect_type"); ok {
 		unfiltered = filtered
 		filtered = []*api.Megaport{}
@@ -108,5 +118,7 @@ func dataSourceMegaportPartnerPortRead(d *schema.ResourceData, m interface{}) er
 		return fmt.Errorf("Multiple partner ports were found. Please use a more specific query.")
 	}
 	d.SetId(filtered[0].ProductUid)
-	return nil
+	attrs := flattenPartnerPort(filtered[0])
+	delete(attrs, "vxc_permitted")
+	return setAttributes(d, attrs)
 }
@@ -0,0 +1,206 @@
+package megaport
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportPartnerPorts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportPartnerPortsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"connect_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"company_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"location_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"min_speed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"diversity_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.DiversityZoneRed, api.DiversityZoneBlue}, false),
+			},
+			"vxc_permitted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"partner_ports": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceMegaportPartnerPortAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMegaportPartnerPortAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"product_uid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"title": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"company_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"company_uid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"connect_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"location_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"speed": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"rank": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"diversity_zone": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"lag_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"lag_primary": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"aggregation_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"vxc_permitted": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+// dataSourceMegaportPartnerPortsRead returns the matching ports sorted by
+// location, rank and title, so that the primary port of each location comes
+// first and the order is stable across runs.
+func dataSourceMegaportPartnerPortsRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	ports, err := cfg.Catalog.PartnerPorts()
+	if err != nil {
+		return err
+	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
+	filtered := []*api.Megaport{}
+	for _, p := range ports {
+		if p.VxcPermitted != d.Get("vxc_permitted").(bool) {
+			continue
+		}
+		if nr != nil && !nr.MatchString(p.Title) {
+			continue
+		}
+		if v, ok := d.GetOk("connect_type"); ok && p.ConnectType != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOk("company_name"); ok && !strings.EqualFold(p.CompanyName, v.(string)) {
+			continue
+		}
+		if v, ok := d.GetOk("location_id"); ok && p.LocationId != uint64(v.(int)) {
+			continue
+		}
+		if v, ok := d.GetOk("min_speed"); ok && p.Speed < uint64(v.(int)) {
+			continue
+		}
+		if v, ok := d.GetOk("diversity_zone"); ok && p.DiversityZone != v.(string) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if a.LocationId != b.LocationId {
+			return a.LocationId < b.LocationId
+		}
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ProductUid < b.ProductUid
+	})
+	uids := make([]string, len(filtered))
+	ret := make([]interface{}, len(filtered))
+	for i, p := range filtered {
+		uids[i] = p.ProductUid
+		ret[i] = flattenPartnerPort(p)
+	}
+	if err := d.Set("partner_ports", ret); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(uids, ","))))
+	return nil
+}
+
+func flattenPartnerPort(p *api.Megaport) map[string]interface{} {
+	return map[string]interface{}{
+		"product_uid":    p.ProductUid,
+		"title":          p.Title,
+		"company_name":   p.CompanyName,
+		"company_uid":    p.CompanyUid,
+		"connect_type":   p.ConnectType,
+		"location_id":    int(p.LocationId),
+		"speed":          int(p.Speed),
+		"rank":           int(p.Rank),
+		"diversity_zone": p.DiversityZone,
+		"lag_id":         int(p.LagId),
+		"lag_primary":    p.LagPrimary,
+		"aggregation_id": int(p.AggregationId),
+		"vxc_permitted":  p.VxcPermitted,
+	}
+}
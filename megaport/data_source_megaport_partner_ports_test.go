@@ -0,0 +1,64 @@
+package megaport
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMegaportPartnerPorts_basic(t *testing.T) {
+	cfg, err := testAccGetConfig("megaport_partner_ports_basic", map[string]interface{}{
+		"location": "Telehouse North",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPartnerPortsSorted("data.megaport_partner_ports.aws"),
+					resource.TestCheckResourceAttr("data.megaport_partner_ports.aws", "partner_ports.0.company_name", "AWS"),
+					resource.TestCheckResourceAttrPair("data.megaport_partner_ports.aws", "partner_ports.0.location_id", "data.megaport_location.aws", "id"),
+					resource.TestCheckResourceAttr("data.megaport_partner_ports.aws_blue", "partner_ports.0.diversity_zone", "blue"),
+					resource.TestCheckResourceAttrPair("data.megaport_partner_port.aws", "id", "data.megaport_partner_ports.aws", "partner_ports.0.product_uid"),
+					resource.TestCheckResourceAttr("data.megaport_partner_port.aws", "company_name", "AWS"),
+					resource.TestCheckResourceAttrPair("data.megaport_partner_port.aws", "speed", "data.megaport_partner_ports.aws", "partner_ports.0.speed"),
+					resource.TestCheckResourceAttrPair("data.megaport_partner_port.aws", "location_id", "data.megaport_location.aws", "id"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckPartnerPortsSorted checks that more than one port was found and
+// that they are sorted by rank.
+func testAccCheckPartnerPortsSorted(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", n)
+		}
+		count, _ := strconv.Atoi(rs.Primary.Attributes["partner_ports.#"])
+		if count < 2 {
+			return fmt.Errorf("expected several partner ports, found %d", count)
+		}
+		prev := 0
+		for i := 0; i < count; i++ {
+			rank, _ := strconv.Atoi(rs.Primary.Attributes[fmt.Sprintf("partner_ports.%d.rank", i)])
+			if rank < prev {
+				return fmt.Errorf("partner port %d is out of order: rank %d after %d", i, rank, prev)
+			}
+			prev = rank
+		}
+		return nil
+	}
+}
@@ -2,61 +2,76 @@ package megaport
 
 import (
 	"fmt"
-	"log"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
-var (
-	megaportPorts []*api.Product
-)
-
 func dataSourceMegaportPort() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceMegaportPortRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(map[string]*schema.Schema{
+			"company_uid": dataSourceAttributeCompanyUid(),
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.ValidateRegexp,
 			},
-		},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"product_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			// provisioning_status is compared case-insensitively, as in
+			// megaport_ports.
+			"provisioning_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		}, dataSourceMegaportPortAttributes()),
 	}
 }
 
-func dataSourceUpdatePorts(c *api.Client) error {
-	megaportMutexKV.Lock("ports")
-	defer megaportMutexKV.Unlock("ports")
-	if megaportPorts != nil {
-		return nil
-	}
-	log.Printf("Updating port list")
-	pp, err := c.ListPorts()
+func dataSourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
 	if err != nil {
 		return err
 	}
-	megaportPorts = pp
-	return nil
-}
-
-func dataSourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
-	cfg := m.(*Config)
-	if err := dataSourceUpdatePorts(cfg.Client); err != nil {
+	ports, err := cfg.catalog(c).Ports()
+	if err != nil {
 		return err
 	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
 	var filtered []*api.Product
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		nr := regexp.MustCompile(nameRegex.(string))
-		for _, port := range megaportPorts {
-			if nr.MatchString(port.ProductName) {
-				filtered = append(filtered, port)
-			}
+	for _, port := range ports {
+		if nr != nil && !nr.MatchString(port.ProductName) {
+			continue
+		}
+		if v, ok := d.GetOk("name"); ok && port.ProductName != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOk("product_uid"); ok && port.ProductUid != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOk("provisioning_status"); ok && !strings.EqualFold(string(port.ProvisioningStatus), v.(string)) {
+			continue
 		}
+		filtered = append(filtered, port)
 	}
 	if len(filtered) < 1 {
 		return fmt.Errorf("No ports were found.")
@@ -65,5 +80,5 @@ func dataSourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Multiple ports were found. Please use a more specific query.")
 	}
 	d.SetId(filtered[0].ProductUid)
-	return nil
+	return setAttributes(d, flattenPort(filtered[0]))
 }
@@ -0,0 +1,456 @@
+package megaport
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportPorts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportPortsRead,
+
+		Schema: map[string]*schema.Schema{
+			"company_uid": dataSourceAttributeCompanyUid(),
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"product_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.ProductKindPort, api.ProductKindMCR1, api.ProductKindMCR2}, false),
+			},
+			"location_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"speed": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			// provisioning_status is compared case-insensitively, as in
+			// megaport_port.
+			"provisioning_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"invoice_reference": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ports": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceMegaportPortAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// dataSourceMegaportPortAttributes describes a port or an MCR. product_type
+// is one of port, mcr1 or mcr2.
+func dataSourceMegaportPortAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"product_uid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"product_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provisioning_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"location_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"market": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"speed": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"term": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"invoice_reference": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"company_uid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"company_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"diversity_zone": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"marketplace_visibility": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vxc_permitted": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"lag_primary": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"create_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"live_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"contract_start_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"contract_end_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"associated_vxcs": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: dataSourceMegaportVxcAttributes(),
+			},
+		},
+		"resources": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interface": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Type:     schema.TypeInt,
+									Computed: true,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"description": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"demarcation": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"loa_template": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"media": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"port_speed": {
+									Type:     schema.TypeInt,
+									Computed: true,
+								},
+								"supported_speeds": {
+									Type:     schema.TypeList,
+									Computed: true,
+									Elem:     &schema.Schema{Type: schema.TypeInt},
+								},
+								"resource_name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"resource_type": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"up": {
+									Type:     schema.TypeBool,
+									Computed: true,
+								},
+							},
+						},
+					},
+					"virtual_router": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Type:     schema.TypeInt,
+									Computed: true,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"mcr_asn": {
+									Type:     schema.TypeInt,
+									Computed: true,
+								},
+								"speed": {
+									Type:     schema.TypeInt,
+									Computed: true,
+								},
+								"resource_name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"resource_type": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMegaportVxcAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"product_uid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provisioning_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"rate_limit": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"invoice_reference": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"a_end": dataSourceAttributeVxcEnd(),
+		"b_end": dataSourceAttributeVxcEnd(),
+	}
+}
+
+func dataSourceAttributeVxcEnd() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"product_uid": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"product_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"owner_uid": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"location_id": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"vlan": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// dataSourceMegaportPortsRead lists the ports and MCRs of the account. Those
+// that have been decommissioned or cancelled are left out, unless they are
+// asked for by provisioning_status.
+func dataSourceMegaportPortsRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	ports, err := cfg.catalog(c).Ports()
+	if err != nil {
+		return err
+	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
+	filtered := []*api.Product{}
+	for _, p := range ports {
+		kind := p.Kind()
+		if kind != api.ProductKindPort && kind != api.ProductKindMCR1 && kind != api.ProductKindMCR2 {
+			continue
+		}
+		if v, ok := d.GetOk("provisioning_status"); ok {
+			if !strings.EqualFold(string(p.ProvisioningStatus), v.(string)) {
+				continue
+			}
+		} else if p.ProvisioningStatus.IsTerminal() {
+			continue
+		}
+		if nr != nil && !nr.MatchString(p.ProductName) {
+			continue
+		}
+		if v, ok := d.GetOk("product_type"); ok && kind != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOk("location_id"); ok && p.LocationId != uint64(v.(int)) {
+			continue
+		}
+		if v, ok := d.GetOk("speed"); ok && p.PortSpeed != uint64(v.(int)) {
+			continue
+		}
+		if v, ok := d.GetOk("invoice_reference"); ok && p.CostCentre != v.(string) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	uids := make([]string, len(filtered))
+	ret := make([]interface{}, len(filtered))
+	for i, p := range filtered {
+		uids[i] = p.ProductUid
+		ret[i] = flattenPort(p)
+	}
+	if err := d.Set("ports", ret); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(uids, ","))))
+	return nil
+}
+
+func flattenPort(p *api.Product) map[string]interface{} {
+	visibility := "private"
+	if p.MarketplaceVisibility {
+		visibility = "public"
+	}
+	vxcs := make([]interface{}, len(p.AssociatedVxcs))
+	for i, v := range p.AssociatedVxcs {
+		vxcs[i] = flattenVxcAttributes(&v)
+	}
+	return map[string]interface{}{
+		"product_uid":            p.ProductUid,
+		"name":                   p.ProductName,
+		"product_type":           p.Kind(),
+		"provisioning_status":    string(p.ProvisioningStatus),
+		"location_id":            int(p.LocationId),
+		"market":                 p.Market,
+		"speed":                  int(p.PortSpeed),
+		"term":                   int(p.ContractTermMonths),
+		"invoice_reference":      p.CostCentre,
+		"company_uid":            p.CompanyUid,
+		"company_name":           p.CompanyName,
+		"diversity_zone":         p.DiversityZone,
+		"marketplace_visibility": visibility,
+		"vxc_permitted":          p.VxcPermitted,
+		"lag_primary":            p.LagPrimary,
+		"create_date":            formatMillis(p.CreateDate),
+		"live_date":              formatMillis(p.LiveDate),
+		"contract_start_date":    formatMillis(p.ContractStartDate),
+		"contract_end_date":      formatMillis(p.ContractEndDate),
+		"associated_vxcs":        vxcs,
+		"resources":              flattenProductResources(p.Resources),
+	}
+}
+
+// flattenProductResources leaves out the resources a product does not have,
+// such as the virtual router of a port.
+func flattenProductResources(r api.ProductResources) []interface{} {
+	ifaces := []interface{}{}
+	if i := r.Interface; i.ResourceType != "" {
+		ifaces = append(ifaces, map[string]interface{}{
+			"id":               int(i.Id),
+			"name":             i.Name,
+			"description":      i.Description,
+			"demarcation":      i.Demarcation,
+			"loa_template":     i.LoaTemplate,
+			"media":            i.Media,
+			"port_speed":       int(i.PortSpeed),
+			"supported_speeds": flattenSpeeds(i.SupportedSpeeds),
+			"resource_name":    i.ResourceName,
+			"resource_type":    i.ResourceType,
+			"up":               i.Up != 0,
+		})
+	}
+	vrs := []interface{}{}
+	if vr := r.VirtualRouter; vr.ResourceType != "" {
+		vrs = append(vrs, map[string]interface{}{
+			"id":            int(vr.Id),
+			"name":          vr.Name,
+			"mcr_asn":       int(vr.McrASN),
+			"speed":         int(vr.Speed),
+			"resource_name": vr.ResourceName,
+			"resource_type": vr.ResourceType,
+		})
+	}
+	return []interface{}{map[string]interface{}{
+		"interface":      ifaces,
+		"virtual_router": vrs,
+	}}
+}
+
+func flattenVxcAttributes(v *api.ProductAssociatedVxc) map[string]interface{} {
+	return map[string]interface{}{
+		"product_uid":         v.ProductUid,
+		"name":                v.ProductName,
+		"provisioning_status": string(v.ProvisioningStatus),
+		"rate_limit":          int(v.RateLimit),
+		"invoice_reference":   v.CostCentre,
+		"a_end":               flattenVxcEndAttributes(v.AEnd),
+		"b_end":               flattenVxcEndAttributes(v.BEnd),
+	}
+}
+
+func flattenVxcEndAttributes(e api.ProductAssociatedVxcEnd) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"product_uid":  e.ProductUid,
+		"product_name": e.ProductName,
+		"owner_uid":    e.OwnerUid,
+		"location_id":  int(e.LocationId),
+		"vlan":         int(e.Vlan),
+	}}
+}
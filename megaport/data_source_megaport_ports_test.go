@@ -0,0 +1,93 @@
+package megaport
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
+)
+
+func TestAccMegaportPorts_basic(t *testing.T) {
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	configValues := map[string]interface{}{
+		"uid":      rName,
+		"location": "Telehouse North",
+	}
+	cfg, err := testAccGetConfig("megaport_ports_basic", configValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+	cfgRead, err := testAccGetConfig("megaport_ports_basic_read", configValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(1, cfgRead)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+			},
+			{
+				Config: cfgRead,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.megaport_ports.foo", "ports.#", "1"),
+					resource.TestCheckResourceAttrPair("data.megaport_ports.foo", "ports.0.product_uid", "megaport_port.foo", "id"),
+					resource.TestCheckResourceAttr("data.megaport_ports.foo", "ports.0.product_type", "port"),
+					resource.TestCheckResourceAttr("data.megaport_ports.foo", "ports.0.speed", "1000"),
+					resource.TestCheckResourceAttr("data.megaport_ports.foo", "ports.0.associated_vxcs.#", "1"),
+					resource.TestCheckResourceAttrPair("data.megaport_ports.foo", "ports.0.associated_vxcs.0.product_uid", "megaport_private_vxc.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.megaport_ports.foo", "ports.0.associated_vxcs.0.b_end.0.product_uid", "megaport_port.bar", "id"),
+					resource.TestCheckResourceAttr("data.megaport_ports.all", "ports.#", "2"),
+					resource.TestCheckResourceAttrPair("data.megaport_port.foo", "id", "megaport_port.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.megaport_port.foo", "product_uid", "megaport_port.foo", "id"),
+					resource.TestCheckResourceAttr("data.megaport_port.foo", "invoice_reference", rName),
+					resource.TestCheckResourceAttrSet("data.megaport_port.foo", "resources.0.interface.0.demarcation"),
+					resource.TestCheckResourceAttr("data.megaport_port.foo", "associated_vxcs.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceMegaportPortsRead_provisioningStatus(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	cfg := testProviderConfig(t, s)
+	live := s.AddPort(&api.Product{ProductName: "live", LocationId: megaporttest.LocationTelehouseNorth, PortSpeed: 1000})
+	decommissioned := s.AddPort(&api.Product{ProductName: "decommissioned", LocationId: megaporttest.LocationTelehouseNorth, PortSpeed: 1000})
+	if err := s.SetProvisioningStatus(decommissioned, api.ProvisioningStatusDecommissioned); err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range []struct {
+		status   string
+		expected []string
+	}{
+		{"", []string{live}},
+		{string(api.ProvisioningStatusDecommissioned), []string{decommissioned}},
+		{"decommissioned", []string{decommissioned}},
+		{string(api.ProvisioningStatusCancelled), []string{}},
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceMegaportPorts().Schema, map[string]interface{}{
+			"provisioning_status": tc.status,
+		})
+		if err := dataSourceMegaportPortsRead(d, cfg); err != nil {
+			t.Fatalf("dataSourceMegaportPortsRead (#%d): %v", i, err)
+		}
+		ports := d.Get("ports").([]interface{})
+		uids := make([]string, len(ports))
+		for j, p := range ports {
+			uids[j] = p.(map[string]interface{})["product_uid"].(string)
+		}
+		if len(uids) != len(tc.expected) || (len(uids) == 1 && uids[0] != tc.expected[0]) {
+			t.Errorf("dataSourceMegaportPortsRead (#%d): unexpected ports: got %v, expected %v", i, uids, tc.expected)
+		}
+	}
+}
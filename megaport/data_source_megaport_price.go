@@ -0,0 +1,183 @@
+package megaport
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+var (
+	priceProductTypes = []string{
+		api.ProductKindPort,
+		api.ProductKindMCR1,
+		api.ProductKindMCR2,
+		strings.ToLower(api.ProductTypeVXC),
+		strings.ToLower(api.ProductTypeIX),
+	}
+)
+
+func dataSourceMegaportPrice() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportPriceRead,
+
+		Schema: map[string]*schema.Schema{
+			"product_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(priceProductTypes, false),
+			},
+			"speed": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"location_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"a_location_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"b_location_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"term": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validation.IntInSlice(productTerms),
+			},
+			"buyout_port": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ix_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"product_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"monthly_total": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"monthly_rate": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"monthly_setup": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"daily_rate": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"daily_setup": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"hourly_rate": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"hourly_setup": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"mbps_rate": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"long_haul_mbps_rate": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"fixed_recurring_charge": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// dataSourceMegaportPriceRead quotes a product from the pricebook. Ports, MCRs
+// and IXs are priced at location_id and VXCs between a_location_id and
+// b_location_id. product_uid prices a change to an existing port or MCR.
+func dataSourceMegaportPriceRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	productType := d.Get("product_type").(string)
+	speed := uint64(d.Get("speed").(int))
+	locationId := uint64(d.Get("location_id").(int))
+	if productType != strings.ToLower(api.ProductTypeVXC) && locationId == 0 {
+		return fmt.Errorf("location_id is required to price a %s", productType)
+	}
+	var (
+		ch  *api.MegaportCharges
+		err error
+	)
+	switch productType {
+	case api.ProductKindPort:
+		ch, err = cfg.Client.GetMegaportPrice(locationId, speed, uint64(d.Get("term").(int)), d.Get("product_uid").(string), d.Get("buyout_port").(bool))
+	case api.ProductKindMCR1:
+		ch, err = cfg.Client.GetMCR1Price(locationId, speed, d.Get("product_uid").(string))
+	case api.ProductKindMCR2:
+		ch, err = cfg.Client.GetMCR2Price(locationId, speed, d.Get("product_uid").(string))
+	case strings.ToLower(api.ProductTypeVXC):
+		a, b := uint64(d.Get("a_location_id").(int)), uint64(d.Get("b_location_id").(int))
+		if a == 0 || b == 0 {
+			return fmt.Errorf("a_location_id and b_location_id are required to price a vxc")
+		}
+		ch, err = cfg.Client.GetVxcPrice(a, b, speed)
+	case strings.ToLower(api.ProductTypeIX):
+		ixType := d.Get("ix_type").(string)
+		if ixType == "" {
+			return fmt.Errorf("ix_type is required to price an ix")
+		}
+		ch, err = cfg.Client.GetIxPrice(ixType, locationId, speed)
+	}
+	if err != nil {
+		return err
+	}
+	if err := setAttributes(d, map[string]interface{}{
+		"currency":               ch.Currency,
+		"monthly_total":          ch.MonthlyTotal(speed),
+		"monthly_rate":           ch.MonthlyRate,
+		"monthly_setup":          ch.MonthlySetup,
+		"daily_rate":             ch.DailyRate,
+		"daily_setup":            ch.DailySetup,
+		"hourly_rate":            ch.HourlyRate,
+		"hourly_setup":           ch.HourlySetup,
+		"mbps_rate":              ch.MbpsRate,
+		"long_haul_mbps_rate":    ch.LongHaulMbpsRate,
+		"fixed_recurring_charge": ch.FixedRecurringCharge,
+	}); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s-%d-%d-%d-%d-%d-%t-%s-%s",
+		productType, speed, locationId, d.Get("a_location_id"), d.Get("b_location_id"), d.Get("term"),
+		d.Get("buyout_port"), d.Get("ix_type"), d.Get("product_uid")))))
+	return nil
+}
@@ -0,0 +1,61 @@
+package megaport
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMegaportPrice_basic(t *testing.T) {
+	cfg, err := testAccGetConfig("megaport_price_basic", map[string]interface{}{
+		"location":   "Telehouse North",
+		"location_b": "Equinix FR5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.megaport_price.port_monthly", "currency"),
+					testAccCheckPriceLess("data.megaport_price.port_yearly", "data.megaport_price.port_monthly"),
+					testAccCheckPriceLess("data.megaport_price.vxc", "data.megaport_price.port_monthly"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckPriceLess checks that the first price is lower than the second.
+func testAccCheckPriceLess(a, b string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		totals := []float64{}
+		for _, n := range []string{a, b} {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("data source not found: %s", n)
+			}
+			v, err := strconv.ParseFloat(rs.Primary.Attributes["monthly_total"], 64)
+			if err != nil {
+				return err
+			}
+			if v <= 0 {
+				return fmt.Errorf("%s has no monthly_total", n)
+			}
+			totals = append(totals, v)
+		}
+		if totals[0] >= totals[1] {
+			return fmt.Errorf("expected %s (%f) to cost less than %s (%f)", a, totals[0], b, totals[1])
+		}
+		return nil
+	}
+}
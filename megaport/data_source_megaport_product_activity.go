@@ -0,0 +1,118 @@
+package megaport
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportProductActivity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMegaportProductActivityRead,
+
+		Schema: map[string]*schema.Schema{
+			"company_uid": dataSourceAttributeCompanyUid(),
+			"product_uid": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"max_events": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interface": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMegaportProductActivityRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	uid := d.Get("product_uid").(string)
+	logs, err := c.GetProductActivity(uid)
+	if err != nil {
+		return err
+	}
+	var since time.Time
+	if v, ok := d.GetOk("since"); ok {
+		since, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	filtered := []*api.ActivityLog{}
+	for _, l := range logs {
+		if len(filtered) == d.Get("max_events").(int) {
+			break
+		}
+		if l.Time().Before(since) {
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+	if err := d.Set("events", flattenActivityLogs(filtered)); err != nil {
+		return err
+	}
+	d.SetId(uid)
+	return nil
+}
+
+func flattenActivityLogs(vs []*api.ActivityLog) []interface{} {
+	ret := make([]interface{}, len(vs))
+	for i, v := range vs {
+		ret[i] = map[string]interface{}{
+			"time":        v.Time().Format(time.RFC3339),
+			"user_name":   v.UserName,
+			"user_email":  v.UserEmail,
+			"interface":   v.Interface,
+			"type":        v.LogType,
+			"message":     v.Message,
+			"description": v.Description,
+		}
+	}
+	return ret
+}
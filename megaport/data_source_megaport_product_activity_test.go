@@ -0,0 +1,37 @@
+package megaport
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMegaportProductActivity_basic(t *testing.T) {
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	cfg, err := testAccGetConfig("megaport_product_activity_basic", map[string]interface{}{
+		"uid":      rName,
+		"location": "Telehouse North",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.megaport_product_activity.foo", "id", "megaport_port.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.megaport_product_activity.foo", "events.0.time"),
+					resource.TestCheckResourceAttrSet("data.megaport_product_activity.foo", "events.0.type"),
+					resource.TestCheckResourceAttrSet("data.megaport_product_activity.foo", "events.0.message"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,264 @@
+package megaport
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func dataSourceMegaportVxc() *schema.Resource {
+	attrs := dataSourceMegaportVxcAttributes()
+	attrs["resources"] = dataSourceAttributeVxcResources()
+	return &schema.Resource{
+		Read: dataSourceMegaportVxcRead,
+
+		Schema: dataSourceSchema(map[string]*schema.Schema{
+			"company_uid": dataSourceAttributeCompanyUid(),
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"product_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		}, attrs),
+	}
+}
+
+func dataSourceAttributeVxcResources() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vll": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"description": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"a_vlan": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"b_vlan": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"rate_limit": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"resource_name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"resource_type": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"up": {
+								Type:     schema.TypeBool,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"aws_virtual_interface": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"vif_id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"type": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"connect_type": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"account": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"owner_account": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"asn": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"amazon_asn": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"peer_asn": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"amazon_ip_address": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"customer_ip_address": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"auth_key": {
+								Type:      schema.TypeString,
+								Computed:  true,
+								Sensitive: true,
+							},
+							"vlan": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceMegaportVxcRead looks a VXC up by product_uid, which finds VXCs
+// of other accounts too, or by name among the VXCs of the ports and MCRs of
+// the account. The VXC is always read in full, since the list of products
+// does not include its resources.
+func dataSourceMegaportVxcRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	var nr *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nr = regexp.MustCompile(v.(string))
+	}
+	match := func(v *api.ProductAssociatedVxc) bool {
+		if v.ProvisioningStatus.IsTerminal() {
+			return false
+		}
+		if nr != nil && !nr.MatchString(v.ProductName) {
+			return false
+		}
+		if n, ok := d.GetOk("name"); ok && v.ProductName != n.(string) {
+			return false
+		}
+		return true
+	}
+	uid := d.Get("product_uid").(string)
+	if uid == "" {
+		ports, err := cfg.catalog(c).Ports()
+		if err != nil {
+			return err
+		}
+		uids := map[string]bool{}
+		for _, p := range ports {
+			for i := range p.AssociatedVxcs {
+				if v := &p.AssociatedVxcs[i]; match(v) {
+					uids[v.ProductUid] = true
+				}
+			}
+		}
+		if len(uids) < 1 {
+			return fmt.Errorf("No VXCs were found.")
+		}
+		if len(uids) > 1 {
+			return fmt.Errorf("Multiple VXCs were found. Please use a more specific query.")
+		}
+		for u := range uids {
+			uid = u
+		}
+	}
+	v, err := c.GetCloudVxc(uid)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(v.ProductType, api.ProductTypeVXC) || !match(v) {
+		return fmt.Errorf("No VXCs were found.")
+	}
+	d.SetId(v.ProductUid)
+	attrs := flattenVxcAttributes(v)
+	attrs["resources"] = flattenVxcResources(v.Resources)
+	return setAttributes(d, attrs)
+}
+
+// flattenVxcResources leaves out the resources a VXC does not have, such as
+// the virtual interface of a VXC that does not connect to AWS.
+func flattenVxcResources(r api.ProductAssociatedVxcResources) []interface{} {
+	vlls := []interface{}{}
+	if vll := r.VLL; vll.ResourceType != "" {
+		vlls = append(vlls, map[string]interface{}{
+			"id":            int(vll.Id),
+			"name":          vll.Name,
+			"description":   vll.Description,
+			"a_vlan":        int(vll.AVLan),
+			"b_vlan":        int(vll.BVLan),
+			"rate_limit":    int(vll.RateLimit),
+			"resource_name": vll.ResourceName,
+			"resource_type": vll.ResourceType,
+			"up":            vll.Up != 0,
+		})
+	}
+	vifs := []interface{}{}
+	if vif := r.AwsVirtualInterface; vif.ResourceType != "" {
+		vifs = append(vifs, map[string]interface{}{
+			"id":                  int(vif.Id),
+			"vif_id":              vif.VifId,
+			"name":                vif.Name,
+			"type":                strings.ToLower(vif.Type),
+			"connect_type":        vif.ConnectType,
+			"account":             vif.Account,
+			"owner_account":       vif.OwnerAccount,
+			"asn":                 int(vif.Asn),
+			"amazon_asn":          int(vif.AmazonAsn),
+			"peer_asn":            int(vif.PeerAsn),
+			"amazon_ip_address":   vif.AmazonIpAddress,
+			"customer_ip_address": vif.CustomerIpAddress,
+			"auth_key":            vif.AuthKey,
+			"vlan":                int(vif.Vlan),
+		})
+	}
+	return []interface{}{map[string]interface{}{
+		"vll":                   vlls,
+		"aws_virtual_interface": vifs,
+	}}
+}
@@ -0,0 +1,57 @@
+package megaport
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMegaportVxc_basic(t *testing.T) {
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	rId := testAccValue(t, "aws_account_id", acctest.RandStringFromCharSet(12, "012346789"))
+	rAsn := testAccValue(t, "customer_asn", strconv.Itoa(acctest.RandIntRange(1, 65535)))
+	configValues := map[string]interface{}{
+		"uid":            rName,
+		"location":       "Equinix LD5",
+		"aws_account_id": rId,
+		"customer_asn":   rAsn,
+	}
+	cfg, err := testAccGetConfig("megaport_aws_vxc_basic", configValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+	cfgRead, err := testAccGetConfig("megaport_vxc_basic_read", configValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(1, cfgRead)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+			},
+			{
+				Config: cfgRead,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.megaport_vxc.uid", "id", "megaport_aws_vxc.foo", "id"),
+					resource.TestCheckResourceAttr("data.megaport_vxc.uid", "name", "terraform_acctest_"+rName),
+					resource.TestCheckResourceAttr("data.megaport_vxc.uid", "rate_limit", "100"),
+					resource.TestCheckResourceAttrPair("data.megaport_vxc.uid", "a_end.0.product_uid", "megaport_port.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.megaport_vxc.uid", "a_end.0.vlan", "megaport_aws_vxc.foo", "a_end.0.vlan"),
+					resource.TestCheckResourceAttr("data.megaport_vxc.uid", "resources.0.vll.0.rate_limit", "100"),
+					resource.TestCheckResourceAttrSet("data.megaport_vxc.uid", "resources.0.aws_virtual_interface.0.vif_id"),
+					resource.TestCheckResourceAttr("data.megaport_vxc.uid", "resources.0.aws_virtual_interface.0.asn", rAsn),
+					resource.TestCheckResourceAttrPair("data.megaport_vxc.uid", "resources.0.aws_virtual_interface.0.amazon_ip_address", "megaport_aws_vxc.foo", "b_end.0.aws_ip_address"),
+					resource.TestCheckResourceAttrPair("data.megaport_vxc.name", "id", "megaport_aws_vxc.foo", "id"),
+				),
+			},
+		},
+	})
+}
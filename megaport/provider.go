@@ -1,20 +1,77 @@
 package megaport
 
 import (
+	"fmt"
 	"log"
+	"sync"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
-var (
-	megaportMutexKV = mutexkv.NewMutexKV()
-)
-
 type Config struct {
-	Client *api.Client
+	Client  *api.Client
+	Catalog *api.Catalog
+
+	mu       sync.Mutex
+	catalogs map[string]*api.Catalog
+	vlans    vlanReservations
+}
+
+// client returns the api client to manage a resource or read a data source
+// with. A company_uid that differs from the one of the provider has to be one
+// of the companies managed by the authenticated company, so that a mistyped
+// uid fails instead of ordering products into the wrong account. It accepts
+// both a *schema.ResourceData and a *schema.ResourceDiff.
+func (c *Config) client(d interface {
+	GetOk(string) (interface{}, bool)
+}) (*api.Client, error) {
+	v, ok := d.GetOk("company_uid")
+	if !ok || v.(string) == c.Client.CompanyUid {
+		return c.Client, nil
+	}
+	mcs, err := c.Catalog.ManagedCompanies()
+	if err != nil {
+		return nil, err
+	}
+	for _, mc := range mcs {
+		if mc.CompanyUid == v.(string) {
+			return c.Client.ForCompany(mc.CompanyUid), nil
+		}
+	}
+	return nil, fmt.Errorf("company %s is not managed by the authenticated company", v.(string))
+}
+
+// catalog returns the catalog of the company that the client acts for, so
+// that the products of one company are never looked up in the lists of
+// another.
+func (c *Config) catalog(client *api.Client) *api.Catalog {
+	if client.CompanyUid == c.Client.CompanyUid {
+		return c.Catalog
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.catalogs == nil {
+		c.catalogs = map[string]*api.Catalog{}
+	}
+	if _, ok := c.catalogs[client.CompanyUid]; !ok {
+		c.catalogs[client.CompanyUid] = api.NewCatalog(client, c.Catalog.TTL)
+	}
+	return c.catalogs[client.CompanyUid]
+}
+
+// invalidatePorts drops the cached ports of every company, since a VXC can
+// change the ports of two of them.
+func (c *Config) invalidatePorts() {
+	c.Catalog.InvalidatePorts()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cat := range c.catalogs {
+		cat.InvalidatePorts()
+	}
 }
 
 func Provider() terraform.ResourceProvider {
@@ -34,28 +91,70 @@ func Provider() terraform.ResourceProvider {
 					"MEGAPORT_API_ENDPOINT",
 				}, api.EndpointProduction),
 			},
+			"company_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"MEGAPORT_COMPANY_UID",
+				}, nil),
+			},
+			"schema_drift": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"MEGAPORT_SCHEMA_DRIFT",
+				}, api.SchemaDriftIgnore),
+				ValidateFunc: validation.StringInSlice([]string{
+					api.SchemaDriftIgnore,
+					api.SchemaDriftLog,
+					api.SchemaDriftFail,
+				}, false),
+			},
+			"catalog_ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"MEGAPORT_CATALOG_TTL",
+				}, "5m"),
+				ValidateFunc: validateDuration,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"megaport_port":        resourceMegaportPort(),
-			"megaport_aws_vxc":     resourceMegaportAwsVxc(),
-			"megaport_private_vxc": resourceMegaportPrivateVxc(),
+			"megaport_port":            resourceMegaportPort(),
+			"megaport_aws_vxc":         resourceMegaportAwsVxc(),
+			"megaport_private_vxc":     resourceMegaportPrivateVxc(),
+			"megaport_vlan_allocation": resourceMegaportVlanAllocation(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"megaport_location":     dataSourceMegaportLocation(),
-			"megaport_partner_port": dataSourceMegaportPartnerPort(),
-			"megaport_port":         dataSourceMegaportPort(),
+			"megaport_internet_exchanges": dataSourceMegaportInternetExchanges(),
+			"megaport_location":           dataSourceMegaportLocation(),
+			"megaport_locations":          dataSourceMegaportLocations(),
+			"megaport_partner_port":       dataSourceMegaportPartnerPort(),
+			"megaport_partner_ports":      dataSourceMegaportPartnerPorts(),
+			"megaport_port":               dataSourceMegaportPort(),
+			"megaport_ports":              dataSourceMegaportPorts(),
+			"megaport_price":              dataSourceMegaportPrice(),
+			"megaport_product_activity":   dataSourceMegaportProductActivity(),
+			"megaport_vxc":                dataSourceMegaportVxc(),
 		},
 
 		ConfigureFunc: func(d *schema.ResourceData) (interface{}, error) {
 			client := api.NewClient(d.Get("api_endpoint").(string))
-			fmt.Printf("initialised megaport api client at %s\n", client.BaseURL)
+			client.SchemaDrift = d.Get("schema_drift").(string)
+			client.CompanyUid = d.Get("company_uid").(string)
+			log.Printf("initialised megaport api client at %s", client.BaseURL)
 			if v, ok := d.GetOk("token"); ok { // TODO: is it an error if not found?
 				client.Token = v.(string)
 			}
+			ttl, err := time.ParseDuration(d.Get("catalog_ttl").(string))
+			if err != nil {
+				return nil, err
+			}
 			return &Config{
-				Client: client,
+				Client:  client,
+				Catalog: api.NewCatalog(client, ttl),
 			}, nil
 		},
 	}
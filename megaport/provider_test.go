@@ -2,20 +2,46 @@ package megaport
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
+)
+
+const (
+	testAccFixturesEnv = "MEGAPORT_TEST_FIXTURES"
 )
 
 var (
 	testAccProviders map[string]terraform.ResourceProvider
 	testAccProvider  *schema.Provider
+
+	testAccFakeServer     *megaporttest.Server
+	testAccFakeServerOnce sync.Once
+
+	testAccRecorder  *megaporttest.Recorder
+	testAccRecorders = map[string]*megaporttest.Recorder{}
 )
 
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
+	configure := testAccProvider.ConfigureFunc
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		m, err := configure(d)
+		if err != nil {
+			return nil, err
+		}
+		if testAccRecorder != nil {
+			m.(*Config).Client.SetTransport(testAccRecorder)
+		}
+		return m, nil
+	}
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"megaport": testAccProvider,
 	}
@@ -37,7 +63,7 @@ func TestProvider(t *testing.T) {
 	if cfg.Client == nil {
 		t.Fatalf("Config does not include a valid Client")
 	}
-	if cfg.Client.BaseURL != api.EndpointStaging {
+	if cfg.Client.BaseURL != testAccEndpoint() {
 		t.Fatalf("Unexpected Provider endpoint: %s", cfg.Client.BaseURL)
 	}
 	if cfg.Client.Token != os.Getenv("MEGAPORT_TOKEN") {
@@ -45,14 +71,163 @@ func TestProvider(t *testing.T) {
 	}
 }
 
-func testAccPreCheck(t *testing.T) {
-	if os.Getenv("MEGAPORT_TOKEN") == "" {
-		t.Fatal("MEGAPORT_TOKEN must be set for acceptance tests")
+func TestProvider_catalogIsolation(t *testing.T) {
+	servers := []*megaporttest.Server{megaporttest.NewServer(), megaporttest.NewServer()}
+	servers[1].AddLocation(&api.Location{Id: 1000, Name: "Extra", Status: api.LocationStatusActive})
+	counts := []int{}
+	for _, s := range servers {
+		defer s.Close()
+		p := Provider().(*schema.Provider)
+		if err := p.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"api_endpoint": s.URL,
+			"token":        s.Token,
+		})); err != nil {
+			t.Fatal(err)
+		}
+		ll, err := p.Meta().(*Config).Catalog.Locations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, len(ll))
 	}
-	if err := os.Setenv("MEGAPORT_API_ENDPOINT", api.EndpointStaging); err != nil {
+	if counts[1] != counts[0]+1 {
+		t.Errorf("Provider catalogs are not isolated: got %d and %d locations", counts[0], counts[1])
+	}
+}
+
+// testProviderConfig configures a provider against the fake server s.
+func testProviderConfig(t *testing.T, s *megaporttest.Server) *Config {
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_endpoint": s.URL,
+		"token":        s.Token,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	return p.Meta().(*Config)
+}
+
+// testAccPreCheck configures the provider against the staging api when
+// MEGAPORT_TOKEN is set and against an in-memory fake of the api otherwise.
+// Setting MEGAPORT_TEST_FIXTURES to "record" additionally records the
+// interactions of each test into testdata/fixtures, while setting it to
+// "replay" serves them from there without contacting any api.
+func testAccPreCheck(t *testing.T) {
+	r := testAccFixture(t)
+	testAccFakeServerOnce.Do(func() {
+		if os.Getenv("MEGAPORT_TOKEN") != "" || (r != nil && r.Mode() == megaporttest.ModeReplay) {
+			return
+		}
+		testAccFakeServer = megaporttest.NewServer()
+		if err := os.Setenv("MEGAPORT_TOKEN", testAccFakeServer.Token); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := os.Setenv("MEGAPORT_API_ENDPOINT", testAccEndpoint()); err != nil {
 		t.Fatal(err)
 	}
 	if err := testAccProvider.Configure(terraform.NewResourceConfigRaw(nil)); err != nil {
 		t.Fatal(err)
 	}
 }
+
+func testAccEndpoint() string {
+	if testAccFakeServer != nil {
+		return testAccFakeServer.URL
+	}
+	return api.EndpointStaging
+}
+
+// testAccFixture returns the recorder of the running test, or nil when
+// MEGAPORT_TEST_FIXTURES is not set. Tests without a recorded fixture are
+// skipped in replay mode.
+func testAccFixture(t *testing.T) *megaporttest.Recorder {
+	mode := os.Getenv(testAccFixturesEnv)
+	if mode == "" {
+		return nil
+	}
+	if r, ok := testAccRecorders[t.Name()]; ok {
+		return r
+	}
+	path := filepath.Join("testdata", "fixtures", strings.Replace(t.Name(), "/", "_", -1)+".json")
+	if _, err := os.Stat(path); mode == megaporttest.ModeReplay && os.IsNotExist(err) {
+		t.Skipf("no fixture has been recorded at %s", path)
+	}
+	r, err := megaporttest.NewRecorder(path, mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccRecorders[t.Name()] = r
+	testAccRecorder = r
+	t.Cleanup(func() {
+		testAccRecorder = nil
+		if t.Failed() {
+			return
+		}
+		if err := r.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+	return r
+}
+
+// testAccValue returns v, unless a value named name has been recorded in the
+// fixture of the running test, so that random values are stable on replay.
+func testAccValue(t *testing.T, name, v string) string {
+	if r := testAccFixture(t); r != nil {
+		return r.Value(name, v)
+	}
+	return v
+}
+
+func TestProvider_companyUid(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	managed := []string{s.AddManagedCompany("foo"), s.AddManagedCompany("bar")}
+	for i, tc := range []struct {
+		provider string
+		resource string
+		expected string
+		err      bool
+	}{
+		{managed[0], "", managed[0], false},
+		{managed[0], managed[0], managed[0], false},
+		{managed[0], managed[1], managed[1], false},
+		{"", "", "", false},
+		{"", managed[1], managed[1], false},
+		{"", uuid.New().String(), "", true},
+		{managed[0], uuid.New().String(), "", true},
+	} {
+		p := Provider().(*schema.Provider)
+		if err := p.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"api_endpoint": s.URL,
+			"token":        s.Token,
+			"company_uid":  tc.provider,
+		})); err != nil {
+			t.Fatal(err)
+		}
+		cfg := p.Meta().(*Config)
+		if cfg.Client.CompanyUid != tc.provider {
+			t.Fatalf("Unexpected Provider company: %s", cfg.Client.CompanyUid)
+		}
+		d := schema.TestResourceDataRaw(t, resourceMegaportPort().Schema, map[string]interface{}{
+			"company_uid": tc.resource,
+		})
+		c, err := cfg.client(d)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Config.client (#%d): expected an error for unmanaged company %s", i, tc.resource)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Config.client (#%d): %v", i, err)
+		}
+		if c.CompanyUid != tc.expected {
+			t.Errorf("Config.client (#%d): unexpected company: got %s, expected %s", i, c.CompanyUid, tc.expected)
+		}
+		if cat := cfg.catalog(c); (cat == cfg.Catalog) != (tc.expected == tc.provider) {
+			t.Errorf("Config.catalog (#%d): unexpected catalog for %q", i, tc.expected)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package megaport
 
 import (
+	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
@@ -15,8 +17,16 @@ func resourceMegaportAwsVxc() *schema.Resource {
 		Update: resourceMegaportAwsVxcUpdate,
 		Delete: resourceMegaportAwsVxcDelete,
 
+		CustomizeDiff: resourceMegaportVxcCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceMegaportAwsVxcImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -25,8 +35,13 @@ func resourceMegaportAwsVxc() *schema.Resource {
 				Required: true,
 			},
 			"rate_limit": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				DiffSuppressFunc: suppressPendingRateLimitDiff,
+			},
+			"pending_rate_limit": {
 				Type:     schema.TypeInt,
-				Required: true,
+				Computed: true,
 			},
 			"a_end": {
 				Type:     schema.TypeList,
@@ -44,6 +59,8 @@ func resourceMegaportAwsVxc() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"company_uid":     resourceAttributeCompanyUid(),
+			"wait_for_status": resourceAttributeWaitForStatus(),
 		},
 	}
 }
@@ -111,15 +128,64 @@ func flattenVxcEndAws(configProductUid string, v api.ProductAssociatedVxcEnd, r
 	}}
 }
 
+// resourceMegaportAwsVxcImport accepts either the uid of the VXC or
+// <vxc_uid>:<partner_port_uid>, for VXCs whose partner port cannot be found
+// from the api alone.
+func resourceMegaportAwsVxcImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+		return nil, fmt.Errorf("unexpected import id %q, expected <vxc_uid> or <vxc_uid>:<partner_port_uid>", d.Id())
+	}
+	d.SetId(parts[0])
+	if len(parts) == 2 {
+		if err := d.Set("b_end", []interface{}{map[string]interface{}{"product_uid": parts[1]}}); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// awsVxcPartnerPortUid finds the partner port that an imported VXC was
+// ordered to. The B-End of the VXC is a product of its own, so the partner
+// port is looked up by its location, connect type and title. Guessing would
+// set a product_uid that replaces the VXC on the next plan, so an ambiguous
+// match is an error instead.
+func awsVxcPartnerPortUid(cfg *Config, v *api.ProductAssociatedVxc) (string, error) {
+	pps, err := cfg.Catalog.PartnerPorts()
+	if err != nil {
+		return "", err
+	}
+	candidates := []*api.Megaport{}
+	for _, pp := range pps {
+		if pp.ProductUid == v.BEnd.ProductUid {
+			return pp.ProductUid, nil
+		}
+		if pp.LocationId != v.BEnd.LocationId || pp.ConnectType != v.Resources.AwsVirtualInterface.ConnectType {
+			continue
+		}
+		if pp.Title == v.BEnd.ProductName {
+			candidates = append(candidates, pp)
+		}
+	}
+	if len(candidates) != 1 {
+		return "", fmt.Errorf("cannot tell which partner port AWS VXC %s was ordered to (%d match its B-End), import it as %s:<partner_port_uid>", v.ProductUid, len(candidates), v.ProductUid)
+	}
+	return candidates[0].ProductUid, nil
+}
+
 func resourceMegaportAwsVxcRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	p, err := cfg.Client.GetCloudVxc(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	p, err := c.GetCloudVxc(d.Id())
 	if err != nil {
 		log.Printf("resourceMegaportAwsVxcRead: %v", err)
 		d.SetId("")
 		return nil
 	}
-	if p.ProvisioningStatus == api.ProductStatusDecommissioned {
+	if p.ProvisioningStatus.IsTerminal() {
 		d.SetId("")
 		return nil
 	}
@@ -129,10 +195,19 @@ func resourceMegaportAwsVxcRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("rate_limit", p.RateLimit); err != nil {
 		return err
 	}
+	pendingRateLimit, _ := p.PendingRateLimit()
+	if err := d.Set("pending_rate_limit", pendingRateLimit); err != nil {
+		return err
+	}
 	if err := d.Set("a_end", flattenVxcEnd(p.AEnd)); err != nil {
 		return err
 	}
-	puid := d.Get("b_end").([]interface{})[0].(map[string]interface{})["product_uid"].(string)
+	puid := d.Get("b_end.0.product_uid").(string)
+	if puid == "" {
+		if puid, err = awsVxcPartnerPortUid(cfg, p); err != nil {
+			return err
+		}
+	}
 	if err := d.Set("b_end", flattenVxcEndAws(puid, p.BEnd, p.Resources)); err != nil {
 		return err
 	}
@@ -144,6 +219,10 @@ func resourceMegaportAwsVxcRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceMegaportAwsVxcCreate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
 	a := d.Get("a_end").([]interface{})[0].(map[string]interface{})
 	b := d.Get("b_end").([]interface{})[0].(map[string]interface{})
 	input := &api.CloudVxcCreateInput{
@@ -166,7 +245,7 @@ func resourceMegaportAwsVxcCreate(d *schema.ResourceData, m interface{}) error {
 	if v := b["aws_connection_name"]; v != "" {
 		inputPartnerConfig.AWSConnectionName = api.String(v)
 	}
-	if v := b["amazon_ip_address"]; v != "" {
+	if v := b["aws_ip_address"]; v != "" {
 		inputPartnerConfig.AmazonIPAddress = api.String(v)
 	}
 	if v := b["bgp_auth_key"]; v != "" {
@@ -176,38 +255,65 @@ func resourceMegaportAwsVxcCreate(d *schema.ResourceData, m interface{}) error {
 		inputPartnerConfig.CustomerIPAddress = api.String(v)
 	}
 	input.PartnerConfig = inputPartnerConfig
-	uid, err := cfg.Client.CreateCloudVxc(input)
+	uid, err := c.CreateCloudVxc(input)
 	if err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
 	d.SetId(*uid)
+	if err := waitForProvisioningStatus(vxcProvisioningStatus(c.GetCloudVxc), d); err != nil {
+		return err
+	}
 	return resourceMegaportAwsVxcRead(d, m)
 }
 
 func resourceMegaportAwsVxcUpdate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
 	a := d.Get("a_end").([]interface{})[0].(map[string]interface{})
 	//b := d.Get("b_end").([]interface{})[0].(map[string]interface{})
-	if err := cfg.Client.UpdateCloudVxc(&api.CloudVxcUpdateInput{
+	input := &api.CloudVxcUpdateInput{
 		InvoiceReference: api.String(d.Get("invoice_reference")),
 		Name:             api.String(d.Get("name")),
 		ProductUid:       api.String(d.Id()),
-		RateLimit:        api.Uint64FromInt(d.Get("rate_limit")),
 		VlanA:            api.Uint64FromInt(a["vlan"]),
-	}); err != nil {
+	}
+	if d.HasChange("rate_limit") {
+		input.RateLimit = api.Uint64FromInt(d.Get("rate_limit"))
+	}
+	pending, err := c.UpdateCloudVxc(input)
+	if err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
+	if pending != nil {
+		if waitErr := waitForVxcSpeedChange(c.GetCloudVxc, d.Id(), uint64(d.Get("rate_limit").(int)), d.Timeout(schema.TimeoutUpdate)); waitErr != nil {
+			if readErr := resourceMegaportAwsVxcRead(d, m); readErr != nil {
+				return readErr
+			}
+			return waitErr
+		}
+	}
 	return resourceMegaportAwsVxcRead(d, m)
 }
 
 func resourceMegaportAwsVxcDelete(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	err := cfg.Client.DeleteCloudVxc(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	err = c.DeleteCloudVxc(d.Id())
+	cfg.invalidatePorts()
 	if err != nil && err != api.ErrNotFound {
 		return err
 	}
 	if err == api.ErrNotFound {
-		log.Printf("resourceMegaportPortDelete: resource not found, deleting anyway")
+		log.Printf("resourceMegaportAwsVxcDelete: resource not found, deleting anyway")
+		return nil
 	}
-	return nil
+	return waitForProvisioningStatusTerminal(vxcProvisioningStatus(c.GetCloudVxc), d)
 }
@@ -1,18 +1,21 @@
 package megaport
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
 )
 
 func TestAccMegaportAwsVxc_basic(t *testing.T) {
 	var vxcBefore api.ProductAssociatedVxc
-	rName := "t" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
-	rId := acctest.RandStringFromCharSet(12, "012346789")
-	rAsn := uint64(acctest.RandIntRange(1, 65535))
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	rId := testAccValue(t, "aws_account_id", acctest.RandStringFromCharSet(12, "012346789"))
+	rAsn := testAccValue(t, "customer_asn", strconv.Itoa(acctest.RandIntRange(1, 65535)))
 
 	cfg, err := testAccGetConfig("megaport_aws_vxc_basic", map[string]interface{}{
 		"uid":            rName,
@@ -35,8 +38,68 @@ func TestAccMegaportAwsVxc_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckResourceExists("megaport_port.foo", &vxcBefore),
 					testAccCheckResourceExists("megaport_aws_vxc.foo", &vxcBefore),
+					resource.TestCheckResourceAttr("megaport_aws_vxc.foo", "b_end.0.aws_ip_address", "10.0.0.1/30"),
+					resource.TestCheckResourceAttr("megaport_aws_vxc.foo", "b_end.0.customer_ip_address", "10.0.0.2/30"),
 				),
 			},
+			{
+				ResourceName:      "megaport_aws_vxc.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "megaport_aws_vxc.foo",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["megaport_aws_vxc.foo"]
+					return rs.Primary.ID + ":" + rs.Primary.Attributes["b_end.0.product_uid"], nil
+				},
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
+
+func TestAwsVxcPartnerPortUid(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	cfg := testProviderConfig(t, s)
+	pps, err := cfg.Catalog.PartnerPorts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aws *api.Megaport
+	for _, pp := range pps {
+		if pp.ConnectType == "AWS" && pp.LocationId == megaporttest.LocationEquinixLD5 {
+			aws = pp
+		}
+	}
+	if aws == nil {
+		t.Fatal("TestAwsVxcPartnerPortUid: cannot find the AWS partner port at Equinix LD5")
+	}
+	for i, tc := range []struct {
+		bEnd     api.ProductAssociatedVxcEnd
+		expected string
+	}{
+		{api.ProductAssociatedVxcEnd{ProductUid: aws.ProductUid}, aws.ProductUid},
+		{api.ProductAssociatedVxcEnd{ProductUid: "foo", LocationId: aws.LocationId, ProductName: aws.Title}, aws.ProductUid},
+		{api.ProductAssociatedVxcEnd{ProductUid: "foo", LocationId: aws.LocationId, ProductName: "bar"}, ""},
+		{api.ProductAssociatedVxcEnd{ProductUid: "foo", LocationId: megaporttest.LocationEquinixSY1, ProductName: aws.Title}, ""},
+	} {
+		v := &api.ProductAssociatedVxc{ProductUid: "baz", BEnd: tc.bEnd}
+		v.Resources.AwsVirtualInterface.ConnectType = "AWS"
+		uid, err := awsVxcPartnerPortUid(cfg, v)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("awsVxcPartnerPortUid (#%d): expected an error, got %s", i, uid)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("awsVxcPartnerPortUid (#%d): %v", i, err)
+		}
+		if uid != tc.expected {
+			t.Errorf("awsVxcPartnerPortUid (#%d): unexpected partner port: got %s, expected %s", i, uid, tc.expected)
+		}
+	}
+}
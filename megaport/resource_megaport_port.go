@@ -1,9 +1,14 @@
 package megaport
 
 import (
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
 )
 
@@ -14,10 +19,17 @@ func resourceMegaportPort() *schema.Resource {
 		Update: resourceMegaportPortUpdate,
 		Delete: resourceMegaportPortDelete,
 
+		CustomizeDiff: resourceMegaportPortCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"location_id": {
 				Type:     schema.TypeInt,
@@ -34,14 +46,24 @@ func resourceMegaportPort() *schema.Resource {
 				ForceNew: true,
 			},
 			"term": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice(productTerms),
 			},
 			"invoice_reference": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"company_uid":     resourceAttributeCompanyUid(),
+			"wait_for_status": resourceAttributeWaitForStatus(),
+			"diversity_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.DiversityZoneRed, api.DiversityZoneBlue}, false),
+			},
 			"associated_vxcs": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -56,12 +78,20 @@ func resourceMegaportPort() *schema.Resource {
 
 func resourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	p, err := cfg.Client.GetPort(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	p, err := c.GetPort(d.Id())
 	if err != nil {
 		log.Printf("resourceMegaportPortRead: %v", err)
 		d.SetId("")
 		return nil
 	}
+	if p.ProvisioningStatus.IsTerminal() {
+		d.SetId("")
+		return nil
+	}
 	if err := d.Set("location_id", p.LocationId); err != nil {
 		return err
 	}
@@ -77,6 +107,9 @@ func resourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("invoice_reference", p.CostCentre); err != nil {
 		return err
 	}
+	if err := d.Set("diversity_zone", p.DiversityZone); err != nil {
+		return err
+	}
 	if err := d.Set("associated_vxcs", schema.NewSet(schema.HashResource(resourceMegaportPrivateVxc()), flattenVxcList(p.AssociatedVxcs))); err != nil {
 		return err
 	}
@@ -93,24 +126,92 @@ func resourceMegaportPortRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceMegaportPortCreate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	uid, err := cfg.Client.CreatePort(&api.PortCreateInput{
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	input := &api.PortCreateInput{
 		LocationId:            api.Uint64FromInt(d.Get("location_id")),
 		MarketplaceVisibility: api.Bool(d.Get("marketplace_visibility") == "public"),
 		Name:                  api.String(d.Get("name")),
 		Speed:                 api.Uint64FromInt(d.Get("speed")),
 		Term:                  api.Uint64FromInt(d.Get("term")),
 		InvoiceReference:      api.String(d.Get("invoice_reference")),
-	})
+	}
+	if v, ok := d.GetOk("diversity_zone"); ok {
+		input.DiversityZone = api.String(v)
+	}
+	uid, err := c.CreatePort(input)
 	if err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
 	d.SetId(*uid)
+	if err := waitForProvisioningStatus(portProvisioningStatus(c), d); err != nil {
+		return err
+	}
 	return resourceMegaportPortRead(d, m)
 }
 
+// resourceMegaportPortCustomizeDiff checks that the port can be ordered at its
+// location, so that plans fail instead of the order. Existing ports are not
+// checked, since locations can stop selling a speed that is already in use.
+func resourceMegaportPortCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("location_id") && !d.HasChange("speed") && !d.HasChange("diversity_zone") {
+		return nil
+	}
+	if !d.NewValueKnown("location_id") || !d.NewValueKnown("speed") {
+		return nil
+	}
+	cfg := m.(*Config)
+	locations, err := cfg.Catalog.Locations()
+	if err != nil {
+		return err
+	}
+	locationId, speed := uint64(d.Get("location_id").(int)), uint64(d.Get("speed").(int))
+	var l *api.Location
+	for _, v := range locations {
+		if v.Id == locationId {
+			l = v
+		}
+	}
+	if l == nil {
+		return fmt.Errorf("location %d does not exist", locationId)
+	}
+	if l.Status != api.LocationStatusActive {
+		return fmt.Errorf("ports cannot be ordered at %s, its status is %q instead of %q", l.Name, l.Status, api.LocationStatusActive)
+	}
+	if !l.SupportsPortSpeed(speed) {
+		speeds := make([]string, len(l.Products.Megaport))
+		for i, s := range l.Products.Megaport {
+			speeds[i] = strconv.FormatUint(s*1000, 10)
+		}
+		return fmt.Errorf("%d Mbps ports are not available at %s, available speeds are %s Mbps", speed, l.Name, strings.Join(speeds, ", "))
+	}
+	zone, ok := d.GetOk("diversity_zone")
+	if !ok || !d.NewValueKnown("diversity_zone") {
+		return nil
+	}
+	// Locations that do not list their zones are left to the api to check.
+	if !l.DiversityZones.Known() {
+		log.Printf("[WARN] %s does not list its diversity zones, cannot check diversity zone %q", l.Name, zone)
+		return nil
+	}
+	for _, z := range l.PortDiversityZones(speed) {
+		if z == zone {
+			return nil
+		}
+	}
+	return fmt.Errorf("diversity zone %q is not available for %d Mbps ports at %s", zone, speed, l.Name)
+}
+
 func resourceMegaportPortUpdate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	if err := cfg.Client.UpdatePort(&api.PortUpdateInput{
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	if err := c.UpdatePort(&api.PortUpdateInput{
 		InvoiceReference:      api.String(d.Get("invoice_reference")),
 		Name:                  api.String(d.Get("name")),
 		ProductUid:            api.String(d.Id()),
@@ -118,19 +219,26 @@ func resourceMegaportPortUpdate(d *schema.ResourceData, m interface{}) error {
 	}); err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
 	return resourceMegaportPortRead(d, m)
 }
 
 func resourceMegaportPortDelete(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	err := cfg.Client.DeletePort(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	err = c.DeletePort(d.Id())
+	cfg.invalidatePorts()
 	if err != nil && err != api.ErrNotFound {
 		return err
 	}
 	if err == api.ErrNotFound {
 		log.Printf("resourceMegaportPortDelete: resource not found, deleting anyway")
+		return nil
 	}
-	return nil
+	return waitForProvisioningStatusTerminal(portProvisioningStatus(c), d)
 }
 
 func flattenVxc(v api.ProductAssociatedVxc) interface{} {
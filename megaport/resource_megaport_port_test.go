@@ -1,16 +1,19 @@
 package megaport
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
 )
 
 func TestAccMegaportPort_basic(t *testing.T) {
 	var port, portUpdated, portNew api.Product
-	rName := "t" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 	configValues := map[string]interface{}{
 		"uid":      rName,
 		"location": "Telehouse North",
@@ -70,6 +73,7 @@ func TestAccMegaportPort_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("megaport_port.foo", "name", "terraform_acctest_"+rName),
 					resource.TestCheckResourceAttr("megaport_port.foo", "speed", "10000"),
 					resource.TestCheckResourceAttr("megaport_port.foo", "term", "12"),
+					resource.TestCheckResourceAttr("megaport_port.foo", "diversity_zone", "red"),
 					resource.TestCheckResourceAttrPair("megaport_port.foo", "location_id", "data.megaport_location.foo", "id"),
 					resource.TestCheckResourceAttr("megaport_port.foo", "invoice_reference", rName),
 					resource.TestCheckNoResourceAttr("megaport_port.foo", "associated_vxcs"),
@@ -86,3 +90,42 @@ func TestAccMegaportPort_basic(t *testing.T) {
 		t.Errorf("TestAccMegaportPort_basic: expected the port to be recreated but the resource ids are identical")
 	}
 }
+
+func TestResourceMegaportPort_customizeDiff(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	cfg := testProviderConfig(t, s)
+	for i, tc := range []struct {
+		locationId int
+		speed      int
+		zone       string
+		err        string
+	}{
+		{megaporttest.LocationTelehouseNorth, 10000, "", ""},
+		{megaporttest.LocationTelehouseNorth, 10000, api.DiversityZoneBlue, ""},
+		{megaporttest.LocationTelehouseNorth, 100000, api.DiversityZoneRed, ""},
+		{megaporttest.LocationTelehouseNorth, 100000, api.DiversityZoneBlue, "diversity zone \"blue\" is not available for 100000 Mbps ports"},
+		{megaporttest.LocationEquinixSY1, 1000, api.DiversityZoneBlue, ""},
+		{megaporttest.LocationTelehouseNorth, 2500, "", "available speeds are 1000, 10000, 100000 Mbps"},
+		{megaporttest.LocationEquinixSY1, 100000, "", "available speeds are 1000, 10000 Mbps"},
+		{megaporttest.LocationDigitalRealty, 1000, "", "its status is \"Deployment\""},
+		{1, 1000, "", "location 1 does not exist"},
+	} {
+		raw := map[string]interface{}{
+			"name":        "foo",
+			"location_id": tc.locationId,
+			"speed":       tc.speed,
+			"term":        1,
+		}
+		if tc.zone != "" {
+			raw["diversity_zone"] = tc.zone
+		}
+		_, err := resourceMegaportPort().Diff(nil, terraform.NewResourceConfigRaw(raw), cfg)
+		if tc.err == "" && err != nil {
+			t.Errorf("resourceMegaportPortCustomizeDiff (#%d): unexpected error: %v", i, err)
+		}
+		if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("resourceMegaportPortCustomizeDiff (#%d): expected an error containing %q, got %v", i, tc.err, err)
+		}
+	}
+}
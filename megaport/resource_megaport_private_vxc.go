@@ -2,6 +2,7 @@ package megaport
 
 import (
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
@@ -14,18 +15,31 @@ func resourceMegaportPrivateVxc() *schema.Resource {
 		Update: resourceMegaportPrivateVxcUpdate,
 		Delete: resourceMegaportPrivateVxcDelete,
 
+		CustomizeDiff: resourceMegaportVxcCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"rate_limit": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				DiffSuppressFunc: suppressPendingRateLimitDiff,
+			},
+			"pending_rate_limit": {
 				Type:     schema.TypeInt,
-				Required: true,
+				Computed: true,
 			},
 			"a_end": {
 				Type:     schema.TypeList,
@@ -43,19 +57,25 @@ func resourceMegaportPrivateVxc() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"company_uid":     resourceAttributeCompanyUid(),
+			"wait_for_status": resourceAttributeWaitForStatus(),
 		},
 	}
 }
 
 func resourceMegaportPrivateVxcRead(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	p, err := cfg.Client.GetPrivateVxc(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	p, err := c.GetPrivateVxc(d.Id())
 	if err != nil {
 		log.Printf("resourceMegaportPrivateVxcRead: %v", err)
 		d.SetId("")
 		return nil
 	}
-	if p.ProvisioningStatus == api.ProductStatusDecommissioned {
+	if p.ProvisioningStatus.IsTerminal() {
 		d.SetId("")
 		return nil
 	}
@@ -65,6 +85,10 @@ func resourceMegaportPrivateVxcRead(d *schema.ResourceData, m interface{}) error
 	if err := d.Set("rate_limit", p.RateLimit); err != nil {
 		return err
 	}
+	pendingRateLimit, _ := p.PendingRateLimit()
+	if err := d.Set("pending_rate_limit", pendingRateLimit); err != nil {
+		return err
+	}
 	if err := d.Set("a_end", flattenVxcEnd(p.AEnd)); err != nil {
 		return err
 	}
@@ -79,9 +103,13 @@ func resourceMegaportPrivateVxcRead(d *schema.ResourceData, m interface{}) error
 
 func resourceMegaportPrivateVxcCreate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
 	a := d.Get("a_end").([]interface{})[0].(map[string]interface{})
 	b := d.Get("b_end").([]interface{})[0].(map[string]interface{})
-	uid, err := cfg.Client.CreatePrivateVxc(&api.PrivateVxcCreateInput{
+	uid, err := c.CreatePrivateVxc(&api.PrivateVxcCreateInput{
 		ProductUidA:      api.String(a["product_uid"]),
 		ProductUidB:      api.String(b["product_uid"]),
 		Name:             api.String(d.Get("name")),
@@ -93,39 +121,66 @@ func resourceMegaportPrivateVxcCreate(d *schema.ResourceData, m interface{}) err
 	if err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
 	d.SetId(*uid)
+	if err := waitForProvisioningStatus(vxcProvisioningStatus(c.GetPrivateVxc), d); err != nil {
+		return err
+	}
 	return resourceMegaportPrivateVxcRead(d, m)
 }
 
 func resourceMegaportPrivateVxcUpdate(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
 	a := d.Get("a_end").([]interface{})[0].(map[string]interface{})
 	b := d.Get("b_end").([]interface{})[0].(map[string]interface{})
 	var vlanB uint64
 	if d.HasChange("b_end.0.vlan") {
 		vlanB = uint64(b["vlan"].(int))
 	}
-	if err := cfg.Client.UpdatePrivateVxc(&api.PrivateVxcUpdateInput{
+	input := &api.PrivateVxcUpdateInput{
 		InvoiceReference: api.String(d.Get("invoice_reference")),
 		Name:             api.String(d.Get("name")),
 		ProductUid:       api.String(d.Id()),
-		RateLimit:        api.Uint64FromInt(d.Get("rate_limit")),
 		VlanA:            api.Uint64FromInt(a["vlan"]),
 		VlanB:            api.Uint64FromInt(vlanB),
-	}); err != nil {
+	}
+	if d.HasChange("rate_limit") {
+		input.RateLimit = api.Uint64FromInt(d.Get("rate_limit"))
+	}
+	pending, err := c.UpdatePrivateVxc(input)
+	if err != nil {
 		return err
 	}
+	cfg.invalidatePorts()
+	if pending != nil {
+		if waitErr := waitForVxcSpeedChange(c.GetPrivateVxc, d.Id(), uint64(d.Get("rate_limit").(int)), d.Timeout(schema.TimeoutUpdate)); waitErr != nil {
+			if readErr := resourceMegaportPrivateVxcRead(d, m); readErr != nil {
+				return readErr
+			}
+			return waitErr
+		}
+	}
 	return resourceMegaportPrivateVxcRead(d, m)
 }
 
 func resourceMegaportPrivateVxcDelete(d *schema.ResourceData, m interface{}) error {
 	cfg := m.(*Config)
-	err := cfg.Client.DeletePrivateVxc(d.Id())
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	err = c.DeletePrivateVxc(d.Id())
+	cfg.invalidatePorts()
 	if err != nil && err != api.ErrNotFound {
 		return err
 	}
 	if err == api.ErrNotFound {
-		log.Printf("resourceMegaportPortDelete: resource not found, deleting anyway")
+		log.Printf("resourceMegaportPrivateVxcDelete: resource not found, deleting anyway")
+		return nil
 	}
-	return nil
+	return waitForProvisioningStatusTerminal(vxcProvisioningStatus(c.GetPrivateVxc), d)
 }
@@ -0,0 +1,180 @@
+package megaport
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+func resourceMegaportVlanAllocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMegaportVlanAllocationCreate,
+		Read:   resourceMegaportVlanAllocationRead,
+		Delete: resourceMegaportVlanAllocationDelete,
+
+		CustomizeDiff: resourceMegaportVlanAllocationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"product_uid": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vlan_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      api.VlanMin,
+				ValidateFunc: validation.IntBetween(api.VlanMin, api.VlanMax),
+			},
+			"vlan_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      api.VlanMax,
+				ValidateFunc: validation.IntBetween(api.VlanMin, api.VlanMax),
+			},
+			"vlan": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"company_uid": resourceAttributeCompanyUid(),
+		},
+	}
+}
+
+// vlanReservations tracks the VLANs handed out by megaport_vlan_allocation,
+// which the api does not know about until a VXC uses them, so that the
+// allocations of a configuration do not hand out the same VLAN twice.
+//
+// The reservations only live in the provider process and are filled in by
+// Read, so they only coordinate allocations that are refreshed and applied
+// together. Applying a saved plan, -refresh=false, -target or allocations
+// in another workspace can hand out a VLAN that another allocation holds
+// and no VXC uses yet; the VXC that uses it second then fails to order.
+//
+// Reservations only keep allocations apart: VXCs are not checked against
+// them, since a VXC that uses an allocation cannot be told apart from one
+// that hard-codes the same VLAN.
+type vlanReservations struct {
+	mu    sync.Mutex
+	vlans map[string]map[uint64]bool
+}
+
+func (r *vlanReservations) add(uid string, vlan uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addLocked(uid, vlan)
+}
+
+func (r *vlanReservations) addLocked(uid string, vlan uint64) {
+	if r.vlans == nil {
+		r.vlans = map[string]map[uint64]bool{}
+	}
+	if r.vlans[uid] == nil {
+		r.vlans[uid] = map[uint64]bool{}
+	}
+	r.vlans[uid][vlan] = true
+}
+
+func (r *vlanReservations) release(uid string, vlan uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.vlans[uid], vlan)
+}
+
+// reserve reserves the lowest VLAN between min and max on the product uid
+// that is neither in use nor already reserved.
+func (r *vlanReservations) reserve(uid string, used map[uint64]bool, min, max uint64) (uint64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for v := min; v <= max; v++ {
+		if !used[v] && !r.vlans[uid][v] {
+			r.addLocked(uid, v)
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func resourceMegaportVlanAllocationRead(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	uid := d.Get("product_uid").(string)
+	p, err := c.GetPort(uid)
+	if err != nil {
+		log.Printf("resourceMegaportVlanAllocationRead: %v", err)
+		d.SetId("")
+		return nil
+	}
+	if p.ProvisioningStatus.IsTerminal() {
+		d.SetId("")
+		return nil
+	}
+	cfg.vlans.add(uid, uint64(d.Get("vlan").(int)))
+	return nil
+}
+
+// resourceMegaportVlanAllocationCreate picks a VLAN that none of the VXCs of
+// the product use and that no other allocation has picked.
+func resourceMegaportVlanAllocationCreate(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	c, err := cfg.client(d)
+	if err != nil {
+		return err
+	}
+	uid := d.Get("product_uid").(string)
+	min, max := uint64(d.Get("vlan_min").(int)), uint64(d.Get("vlan_max").(int))
+	p, err := c.GetPort(uid)
+	if err != nil {
+		return err
+	}
+	if p.ProvisioningStatus.IsTerminal() {
+		return fmt.Errorf("cannot allocate a VLAN on %s, its status is %s", uid, p.ProvisioningStatus)
+	}
+	used := map[uint64]bool{}
+	for _, v := range p.AssociatedVxcs {
+		if v.ProvisioningStatus.IsTerminal() {
+			continue
+		}
+		if v.AEnd.ProductUid == uid {
+			used[v.AEnd.Vlan] = true
+		}
+		if v.BEnd.ProductUid == uid {
+			used[v.BEnd.Vlan] = true
+		}
+	}
+	vlan, ok := cfg.vlans.reserve(uid, used, min, max)
+	if !ok {
+		return fmt.Errorf("there are no free VLANs between %d and %d on %s", min, max, p.ProductName)
+	}
+	d.SetId(fmt.Sprintf("%s:%d", uid, vlan))
+	if err := d.Set("vlan", vlan); err != nil {
+		return err
+	}
+	return resourceMegaportVlanAllocationRead(d, m)
+}
+
+func resourceMegaportVlanAllocationCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("vlan_min") || !d.NewValueKnown("vlan_max") {
+		return nil
+	}
+	min, max := d.Get("vlan_min").(int), d.Get("vlan_max").(int)
+	if min > max {
+		return fmt.Errorf("vlan_min (%d) is greater than vlan_max (%d)", min, max)
+	}
+	return nil
+}
+
+func resourceMegaportVlanAllocationDelete(d *schema.ResourceData, m interface{}) error {
+	cfg := m.(*Config)
+	cfg.vlans.release(d.Get("product_uid").(string), uint64(d.Get("vlan").(int)))
+	return nil
+}
@@ -0,0 +1,86 @@
+package megaport
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMegaportVlanAllocation_basic(t *testing.T) {
+	rName := testAccValue(t, "uid", "t"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	cfg, err := testAccGetConfig("megaport_vlan_allocation_basic", map[string]interface{}{
+		"uid":      rName,
+		"location": "Telehouse North",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccLogConfig(0, cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("megaport_private_vxc.foo", "a_end.0.vlan", "megaport_vlan_allocation.foo", "vlan"),
+					resource.TestCheckResourceAttrPair("megaport_private_vxc.bar", "a_end.0.vlan", "megaport_vlan_allocation.bar", "vlan"),
+					testAccCheckVlanAllocationsDistinct("megaport_vlan_allocation.foo", "megaport_vlan_allocation.bar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVlanAllocationsDistinct(a, b string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vlans := []string{}
+		for _, n := range []string{a, b} {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("testAccCheckVlanAllocationsDistinct: cannot find %q", n)
+			}
+			vlans = append(vlans, rs.Primary.Attributes["vlan"])
+		}
+		if vlans[0] == vlans[1] {
+			return fmt.Errorf("testAccCheckVlanAllocationsDistinct: %s and %s both allocated VLAN %s", a, b, vlans[0])
+		}
+		return nil
+	}
+}
+
+// testUnknownValue marks a value that is not known until apply in a raw
+// config, as the sdk does internally.
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceMegaportVlanAllocation_customizeDiff(t *testing.T) {
+	for i, tc := range []struct {
+		min, max interface{}
+		err      string
+	}{
+		{100, 109, ""},
+		{100, 100, ""},
+		{110, 109, "vlan_min (110) is greater than vlan_max (109)"},
+		{110, testUnknownValue, ""},
+		{testUnknownValue, 109, ""},
+	} {
+		raw := map[string]interface{}{
+			"product_uid": "foo",
+			"vlan_min":    tc.min,
+			"vlan_max":    tc.max,
+		}
+		_, err := resourceMegaportVlanAllocation().Diff(nil, terraform.NewResourceConfigRaw(raw), &Config{})
+		if tc.err == "" && err != nil {
+			t.Errorf("resourceMegaportVlanAllocationCustomizeDiff (#%d): unexpected error: %v", i, err)
+		}
+		if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("resourceMegaportVlanAllocationCustomizeDiff (#%d): expected an error containing %q, got %v", i, tc.err, err)
+		}
+	}
+}
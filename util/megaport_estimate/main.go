@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+const (
+	usage = `usage: megaport_estimate [--json] <plan.json>
+
+Estimates the monthly cost of the Megaport products in a Terraform plan, as
+produced by:
+
+  $ terraform plan -out plan.out
+  $ terraform show -json plan.out > plan.json
+
+Use - to read the plan from stdin. MEGAPORT_TOKEN and MEGAPORT_API_ENDPOINT
+are used to access the api, as with the provider.`
+)
+
+func main() {
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	asJSON := flag.Bool("json", false, "print the estimate as json")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatalln(usage)
+	}
+	var b []byte
+	var err error
+	if flag.Arg(0) == "-" {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(flag.Arg(0))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	p, err := parsePlan(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	endpoint := os.Getenv("MEGAPORT_API_ENDPOINT")
+	if endpoint == "" {
+		endpoint = api.EndpointProduction
+	}
+	c := api.NewClient(endpoint)
+	c.Token = os.Getenv("MEGAPORT_TOKEN")
+	d, err := p.design(apiLocator(c))
+	if err != nil {
+		log.Fatal(err)
+	}
+	e, err := c.EstimateDesign(d)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(e); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "RESOURCE\tTYPE\tSPEED (Mbps)\tMONTHLY (%s)\tSETUP (%s)\t\n", e.Currency, e.Currency)
+	for _, i := range e.Items {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.2f\t%.2f\t\n", i.Name, i.ProductType, i.Speed, i.MonthlyRate, i.MonthlySetup)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t%.2f\t%.2f\t\n", e.MonthlyRate, e.MonthlySetup)
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+)
+
+var (
+	instanceKeyRe = regexp.MustCompile(`\[[^\]]*\]`)
+)
+
+// plan is the subset of the output of `terraform show -json` that is needed
+// to price the products of a configuration.
+type plan struct {
+	ResourceChanges []*resourceChange `json:"resource_changes"`
+	Configuration   struct {
+		RootModule *configModule `json:"root_module"`
+	} `json:"configuration"`
+}
+
+type resourceChange struct {
+	Address       string `json:"address"`
+	ModuleAddress string `json:"module_address"`
+	Mode          string `json:"mode"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Change        struct {
+		Actions []string               `json:"actions"`
+		After   map[string]interface{} `json:"after"`
+	} `json:"change"`
+}
+
+type configModule struct {
+	Resources   []*configResource `json:"resources"`
+	ModuleCalls map[string]*struct {
+		Module *configModule `json:"module"`
+	} `json:"module_calls"`
+}
+
+type configResource struct {
+	Address     string                 `json:"address"`
+	Expressions map[string]interface{} `json:"expressions"`
+}
+
+// productLocator returns the location of an existing product.
+type productLocator func(uid string) (uint64, error)
+
+func parsePlan(b []byte) (*plan, error) {
+	p := &plan{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("cannot parse plan: %w", err)
+	}
+	return p, nil
+}
+
+// design returns the products that exist once the plan is applied, leaving
+// out those that it deletes. The locations of VXC ends are looked up for
+// existing products and taken from the plan for ports that it creates.
+func (p *plan) design(locate productLocator) (*api.Design, error) {
+	d := &api.Design{}
+	ports := map[string]uint64{}
+	changes := []*resourceChange{}
+	for _, rc := range p.ResourceChanges {
+		if rc.Mode != "managed" || rc.Change.After == nil || isDelete(rc.Change.Actions) {
+			continue
+		}
+		changes = append(changes, rc)
+		if rc.Type != "megaport_port" {
+			continue
+		}
+		port := &api.PortDesign{
+			Name:       rc.Address,
+			LocationId: uintAttr(rc.Change.After, "location_id"),
+			Speed:      uintAttr(rc.Change.After, "speed"),
+			Term:       uintAttr(rc.Change.After, "term"),
+		}
+		d.Ports = append(d.Ports, port)
+		ports[configAddress(rc.ModuleAddress, rc.Type+"."+rc.Name)] = port.LocationId
+	}
+	for _, rc := range changes {
+		if rc.Type != "megaport_private_vxc" && rc.Type != "megaport_aws_vxc" {
+			continue
+		}
+		v := &api.VxcDesign{
+			Name:      rc.Address,
+			RateLimit: uintAttr(rc.Change.After, "rate_limit"),
+		}
+		for _, end := range []string{"a_end", "b_end"} {
+			l, err := p.endLocation(rc, end, ports, locate)
+			if err != nil {
+				return nil, err
+			}
+			if end == "a_end" {
+				v.ALocationId = l
+			} else {
+				v.BLocationId = l
+			}
+		}
+		d.VXCs = append(d.VXCs, v)
+	}
+	return d, nil
+}
+
+func (p *plan) endLocation(rc *resourceChange, end string, ports map[string]uint64, locate productLocator) (uint64, error) {
+	if uid, ok := blockAttr(rc.Change.After, end, "product_uid").(string); ok && uid != "" {
+		return locate(uid)
+	}
+	refs := []string{}
+	if cr := p.configResource(rc.ModuleAddress, rc.Type+"."+rc.Name); cr != nil {
+		if e, ok := blockAttr(cr.Expressions, end, "product_uid").(map[string]interface{}); ok {
+			if rr, ok := e["references"].([]interface{}); ok {
+				for _, r := range rr {
+					if s, ok := r.(string); ok {
+						refs = append(refs, s)
+					}
+				}
+			}
+		}
+	}
+	for _, r := range refs {
+		parts := strings.Split(r, ".")
+		if len(parts) < 2 || parts[0] != "megaport_port" {
+			continue
+		}
+		if l, ok := ports[configAddress(rc.ModuleAddress, parts[0]+"."+parts[1])]; ok {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot find the location of %s of %s", end, rc.Address)
+}
+
+func (p *plan) configResource(moduleAddress, address string) *configResource {
+	m := p.Configuration.RootModule
+	if moduleAddress != "" {
+		for _, part := range strings.Split(instanceKeyRe.ReplaceAllString(moduleAddress, ""), ".") {
+			if part == "module" {
+				continue
+			}
+			if m == nil || m.ModuleCalls[part] == nil {
+				return nil
+			}
+			m = m.ModuleCalls[part].Module
+		}
+	}
+	if m == nil {
+		return nil
+	}
+	for _, r := range m.Resources {
+		if r.Address == address {
+			return r
+		}
+	}
+	return nil
+}
+
+// configAddress identifies a resource in the configuration, which is shared by
+// all of its instances.
+func configAddress(moduleAddress, address string) string {
+	if moduleAddress == "" {
+		return address
+	}
+	return instanceKeyRe.ReplaceAllString(moduleAddress, "") + "." + address
+}
+
+func isDelete(actions []string) bool {
+	return len(actions) == 1 && actions[0] == "delete"
+}
+
+func uintAttr(m map[string]interface{}, k string) uint64 {
+	if v, ok := m[k].(float64); ok && v > 0 {
+		return uint64(v)
+	}
+	return 0
+}
+
+// blockAttr returns an attribute of the first element of a nested block.
+func blockAttr(m map[string]interface{}, block, k string) interface{} {
+	bb, ok := m[block].([]interface{})
+	if !ok || len(bb) == 0 {
+		return nil
+	}
+	b, ok := bb[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return b[k]
+}
+
+// apiLocator locates the ports of the account and the partner ports, which are
+// the products VXCs can be connected to.
+func apiLocator(c *api.Client) productLocator {
+	var locations map[string]uint64
+	return func(uid string) (uint64, error) {
+		if locations == nil {
+			ports, err := c.ListPorts()
+			if err != nil {
+				return 0, err
+			}
+			partnerPorts, err := c.GetMegaports()
+			if err != nil {
+				return 0, err
+			}
+			locations = map[string]uint64{}
+			for _, p := range ports {
+				locations[p.ProductUid] = p.LocationId
+			}
+			for _, p := range partnerPorts {
+				locations[p.ProductUid] = p.LocationId
+			}
+		}
+		l, ok := locations[uid]
+		if !ok {
+			return 0, fmt.Errorf("cannot find product %s", uid)
+		}
+		return l, nil
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api"
+	"github.com/utilitywarehouse/terraform-provider-megaport/megaport/api/megaporttest"
+)
+
+func TestPlan_design(t *testing.T) {
+	s := megaporttest.NewServer()
+	defer s.Close()
+	s.AddPort(&api.Product{
+		ProductUid:  "7e57c0de-0000-4000-8000-000000000003",
+		ProductName: "remote",
+		LocationId:  megaporttest.LocationEquinixSY1,
+		PortSpeed:   10000,
+	})
+	b, err := ioutil.ReadFile("testdata/plan.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := parsePlan(b)
+	if err != nil {
+		t.Fatalf("TestPlan_design: %v", err)
+	}
+	c := s.Client()
+	d, err := p.design(apiLocator(c))
+	if err != nil {
+		t.Fatalf("TestPlan_design: %v", err)
+	}
+	if len(d.Ports) != 2 || d.Ports[0].Name != "megaport_port.foo" || d.Ports[1].LocationId != megaporttest.LocationEquinixFR5 {
+		t.Errorf("TestPlan_design: unexpected ports: %+v", d.Ports)
+	}
+	if len(d.VXCs) != 2 {
+		t.Fatalf("TestPlan_design: unexpected vxcs: %+v", d.VXCs)
+	}
+	if v := d.VXCs[0]; v.ALocationId != megaporttest.LocationTelehouseNorth || v.BLocationId != megaporttest.LocationEquinixLD5 || v.RateLimit != 100 {
+		t.Errorf("TestPlan_design: unexpected aws vxc: %+v", v)
+	}
+	if v := d.VXCs[1]; v.ALocationId != megaporttest.LocationEquinixFR5 || v.BLocationId != megaporttest.LocationEquinixSY1 || v.RateLimit != 200 {
+		t.Errorf("TestPlan_design: unexpected private vxc: %+v", v)
+	}
+	e, err := c.EstimateDesign(d)
+	if err != nil {
+		t.Fatalf("TestPlan_design: %v", err)
+	}
+	if e.Currency != megaporttest.Currency || math.Abs(e.MonthlyRate-1200) > 1e-9 || e.MonthlySetup != 500 {
+		t.Errorf("TestPlan_design: unexpected estimate: %+v", e)
+	}
+}
+
+func TestPlan_designUnresolved(t *testing.T) {
+	p, err := parsePlan([]byte(`{"resource_changes":[{"address":"megaport_private_vxc.foo","mode":"managed","type":"megaport_private_vxc","name":"foo","change":{"actions":["create"],"after":{"a_end":[{}],"b_end":[{}]}}}]}`))
+	if err != nil {
+		t.Fatalf("TestPlan_designUnresolved: %v", err)
+	}
+	if _, err := p.design(func(string) (uint64, error) { return 1, nil }); err == nil {
+		t.Errorf("TestPlan_designUnresolved: expected an error for a vxc end that cannot be located")
+	}
+}